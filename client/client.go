@@ -27,11 +27,17 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/liteseed/goar/currency"
+	"github.com/liteseed/goar/internal/logging"
+	"github.com/liteseed/goar/metrics"
 	"github.com/liteseed/goar/transaction"
 )
 
@@ -40,9 +46,15 @@ import (
 // The client maintains connection settings and provides methods for all
 // Arweave HTTP API endpoints. It includes automatic timeout handling
 // and error management for network operations.
+//
+// Every request is logged at debug level to Logger, if set, with its method,
+// URL, status and duration, and recorded to Metrics as HTTP_REQUESTS,
+// HTTP_REQUEST_DURATION and UPLOADED_BYTES. Use adds tracing middlewares.
 type Client struct {
-	Client  *http.Client // HTTP client with configured timeout
-	Gateway string       // Base URL of the Arweave gateway
+	Client  *http.Client     // HTTP client with configured timeout
+	Gateway string           // Base URL of the Arweave gateway
+	Logger  *slog.Logger     // Optional logger for HTTP calls (nil discards them)
+	Metrics metrics.Recorder // Optional recorder for HTTP calls (nil discards them)
 }
 
 // New creates a new Arweave client with default settings.
@@ -67,6 +79,16 @@ func New(gateway string) *Client {
 	}
 }
 
+// logger returns the Logger of the client, or a logger that discards records.
+func (c *Client) logger() *slog.Logger {
+	return logging.OrDiscard(c.Logger)
+}
+
+// recorder returns the Metrics of the client, or a recorder that discards measurements.
+func (c *Client) recorder() metrics.Recorder {
+	return metrics.OrNop(c.Metrics)
+}
+
 // GetTransactionByID retrieves a complete transaction by its ID.
 //
 // This method fetches the full transaction data including all fields
@@ -109,8 +131,10 @@ func (c *Client) GetTransactionByID(id string) (*transaction.Transaction, error)
 // Parameters:
 //   - id: The transaction ID to check status for
 //
-// Returns TransactionStatus with confirmation details or an error if
-// the transaction cannot be found.
+// Returns TransactionStatus with confirmation details, or an error if
+// the transaction cannot be found (wrapping ErrNotFound). Transactions that
+// are known to the node but not mined yet return a status with Confirmed
+// set to false.
 //
 // Example:
 //
@@ -123,16 +147,29 @@ func (c *Client) GetTransactionByID(id string) (*transaction.Transaction, error)
 //		fmt.Printf("Transaction confirmed in block %s\n", status.BlockIndepHash)
 //	}
 func (c *Client) GetTransactionStatus(id string) (*TransactionStatus, error) {
-	body, err := c.get(fmt.Sprintf("tx/%s/status", id))
+	return c.GetTransactionStatusContext(context.Background(), id)
+}
+
+// GetTransactionStatusContext is like GetTransactionStatus but uses ctx for the request.
+func (c *Client) GetTransactionStatusContext(ctx context.Context, id string) (*TransactionStatus, error) {
+	code, body, err := c.do(ctx, http.MethodGet, fmt.Sprintf("tx/%s/status", id), nil)
 	if err != nil {
 		return nil, err
 	}
+	if err = statusError(code, body); err != nil {
+		return nil, err
+	}
 
+	// 202 Accepted: the transaction is pending in the mempool
 	t := &TransactionStatus{}
+	if code == http.StatusAccepted {
+		return t, nil
+	}
 	err = json.Unmarshal(body, t)
 	if err != nil {
 		return nil, err
 	}
+	t.Confirmed = t.BlockIndepHash != ""
 	return t, nil
 }
 
@@ -205,8 +242,8 @@ func (c *Client) GetTransactionData(id string) ([]byte, error) {
 //   - size: The size of data in bytes
 //   - target: Optional target address (use empty string if not applicable)
 //
-// Returns the transaction fee in Winston as a string, or an error if
-// the price cannot be calculated.
+// Returns the transaction fee in Winston, or an error if the price cannot
+// be calculated.
 //
 // Example:
 //
@@ -216,14 +253,19 @@ func (c *Client) GetTransactionData(id string) ([]byte, error) {
 //		return
 //	}
 //	fmt.Printf("Cost for 1KB: %s Winston\n", price)
-func (c *Client) GetTransactionPrice(size int, target string) (string, error) {
+func (c *Client) GetTransactionPrice(size int, target string) (currency.Winston, error) {
+	return c.GetTransactionPriceContext(context.Background(), size, target)
+}
+
+// GetTransactionPriceContext is like GetTransactionPrice but uses ctx for the request.
+func (c *Client) GetTransactionPriceContext(ctx context.Context, size int, target string) (currency.Winston, error) {
 	url := fmt.Sprintf("price/%d/%s", size, target)
-	body, err := c.get(url)
+	body, err := c.getContext(ctx, url)
 	if err != nil {
-		return "", err
+		return currency.Zero, err
 	}
 
-	return string(body), nil
+	return currency.ParseWinston(strings.TrimSpace(string(body)))
 }
 
 // GetTransactionAnchor retrieves the current transaction anchor.
@@ -244,7 +286,12 @@ func (c *Client) GetTransactionPrice(size int, target string) (string, error) {
 //	}
 //	fmt.Printf("Current anchor: %s\n", anchor)
 func (c *Client) GetTransactionAnchor() (string, error) {
-	body, err := c.get("tx_anchor")
+	return c.GetTransactionAnchorContext(context.Background())
+}
+
+// GetTransactionAnchorContext is like GetTransactionAnchor but uses ctx for the request.
+func (c *Client) GetTransactionAnchorContext(ctx context.Context) (string, error) {
+	body, err := c.getContext(ctx, "tx_anchor")
 	if err != nil {
 		return "", err
 	}
@@ -274,11 +321,16 @@ func (c *Client) GetTransactionAnchor() (string, error) {
 //		fmt.Println("Transaction submitted successfully")
 //	}
 func (c *Client) SubmitTransaction(tx *transaction.Transaction) (int, error) {
+	return c.SubmitTransactionContext(context.Background(), tx)
+}
+
+// SubmitTransactionContext is like SubmitTransaction but uses ctx for the request.
+func (c *Client) SubmitTransactionContext(ctx context.Context, tx *transaction.Transaction) (int, error) {
 	b, err := json.Marshal(tx)
 	if err != nil {
 		return -1, err
 	}
-	return c.post("tx", b)
+	return c.postContext(ctx, "tx", b)
 }
 
 // GetWalletBalance retrieves the current AR token balance for a wallet.
@@ -290,8 +342,8 @@ func (c *Client) SubmitTransaction(tx *transaction.Transaction) (int, error) {
 // Parameters:
 //   - address: The wallet address to query (base64url-encoded public key hash)
 //
-// Returns the wallet balance in Winston as a string, or an error if
-// the address is invalid or cannot be queried.
+// Returns the wallet balance in Winston, or an error if the address is
+// invalid or cannot be queried.
 //
 // Example:
 //
@@ -300,13 +352,18 @@ func (c *Client) SubmitTransaction(tx *transaction.Transaction) (int, error) {
 //		log.Printf("Failed to get balance: %v", err)
 //		return
 //	}
-//	fmt.Printf("Wallet balance: %s Winston\n", balance)
-func (c *Client) GetWalletBalance(address string) (string, error) {
-	body, err := c.get(fmt.Sprintf("wallet/%s/balance", address))
+//	fmt.Printf("Wallet balance: %s AR\n", balance.AR())
+func (c *Client) GetWalletBalance(address string) (currency.Winston, error) {
+	return c.GetWalletBalanceContext(context.Background(), address)
+}
+
+// GetWalletBalanceContext is like GetWalletBalance but uses ctx for the request.
+func (c *Client) GetWalletBalanceContext(ctx context.Context, address string) (currency.Winston, error) {
+	body, err := c.getContext(ctx, fmt.Sprintf("wallet/%s/balance", address))
 	if err != nil {
-		return "", err
+		return currency.Zero, err
 	}
-	return string(body), nil
+	return currency.ParseWinston(strings.TrimSpace(string(body)))
 }
 
 // GetLastTransactionID retrieves the last transaction ID for a wallet.
@@ -358,7 +415,12 @@ func (c *Client) GetLastTransactionID(address string) (string, error) {
 //	}
 //	fmt.Printf("Block height: %d, TX count: %d\n", block.Height, len(block.Txs))
 func (c *Client) GetBlockByID(id string) (*Block, error) {
-	body, err := c.get(fmt.Sprintf("block/hash/%s", id))
+	return c.GetBlockByIDContext(context.Background(), id)
+}
+
+// GetBlockByIDContext is like GetBlockByID but uses ctx for the request.
+func (c *Client) GetBlockByIDContext(ctx context.Context, id string) (*Block, error) {
+	body, err := c.getContext(ctx, fmt.Sprintf("block/hash/%s", id))
 	if err != nil {
 		return nil, err
 	}
@@ -421,7 +483,12 @@ func (c *Client) GetBlockByHeight(height string) (*Block, error) {
 //	}
 //	fmt.Printf("Network height: %d, Peers: %d\n", info.Height, info.Peers)
 func (c *Client) GetNetworkInfo() (*NetworkInfo, error) {
-	body, err := c.get("info")
+	return c.GetNetworkInfoContext(context.Background())
+}
+
+// GetNetworkInfoContext is like GetNetworkInfo but uses ctx for the request.
+func (c *Client) GetNetworkInfoContext(ctx context.Context) (*NetworkInfo, error) {
+	body, err := c.getContext(ctx, "info")
 	if err != nil {
 		return nil, err
 	}
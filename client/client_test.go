@@ -2,9 +2,9 @@ package client
 
 import (
 	"errors"
-	"strconv"
 	"testing"
 
+	"github.com/liteseed/goar/currency"
 	"github.com/liteseed/goar/signer"
 	"github.com/liteseed/goar/tag"
 	"github.com/liteseed/goar/transaction"
@@ -34,7 +34,7 @@ func createTransaction(t *testing.T, c *Client) *transaction.Transaction {
 
 	mint(t, c, s.Address)
 
-	tx := transaction.New(data, "", "0", nil)
+	tx := transaction.New(data, "", currency.Zero, nil)
 	assert.NoError(t, err)
 
 	tx.Owner = s.Owner()
@@ -99,8 +99,7 @@ func TestGetTransactionPrice(t *testing.T) {
 	c := New("http://localhost:1984")
 	res, err := c.GetTransactionPrice(0, "")
 	assert.NoError(t, err)
-	_, err = strconv.Atoi(res)
-	assert.NoError(t, err)
+	assert.Equal(t, 1, res.Sign())
 }
 
 func TestGetTransactionAnchor(t *testing.T) {
@@ -122,7 +121,7 @@ func TestSubmitTransaction(t *testing.T) {
 	mint(t, c, s.Address)
 
 	t.Run("Post with Data", func(t *testing.T) {
-		tx := transaction.New(data, "", "0", nil)
+		tx := transaction.New(data, "", currency.Zero, nil)
 		assert.NoError(t, err)
 
 		tx.Owner = s.Owner()
@@ -143,7 +142,7 @@ func TestSubmitTransaction(t *testing.T) {
 	})
 
 	t.Run("Post with Data & Tags", func(t *testing.T) {
-		tx := transaction.New(data, "", "0", tags)
+		tx := transaction.New(data, "", currency.Zero, tags)
 		assert.NoError(t, err)
 
 		tx.Owner = s.Owner()
@@ -164,7 +163,7 @@ func TestSubmitTransaction(t *testing.T) {
 	})
 
 	t.Run("Post with Target & Quantity", func(t *testing.T) {
-		tx := transaction.New(nil, "Cbj95zDZBBhmyht6iFlEf7xmSCSVZGw436V6HWmm9Ek", "1000", nil)
+		tx := transaction.New(nil, "Cbj95zDZBBhmyht6iFlEf7xmSCSVZGw436V6HWmm9Ek", currency.NewWinston(1000), nil)
 		assert.NoError(t, err)
 
 		tx.Owner = s.Owner()
@@ -185,7 +184,7 @@ func TestSubmitTransaction(t *testing.T) {
 	})
 
 	t.Run("Post with Target, Quantity, & Tags", func(t *testing.T) {
-		tx := transaction.New(nil, "Cbj95zDZBBhmyht6iFlEf7xmSCSVZGw436V6HWmm9Ek", "1000", tags)
+		tx := transaction.New(nil, "Cbj95zDZBBhmyht6iFlEf7xmSCSVZGw436V6HWmm9Ek", currency.NewWinston(1000), tags)
 		assert.NoError(t, err)
 
 		tx.Owner = s.Owner()
@@ -206,7 +205,7 @@ func TestSubmitTransaction(t *testing.T) {
 	})
 
 	t.Run("Post with Everything", func(t *testing.T) {
-		tx := transaction.New(data, "Cbj95zDZBBhmyht6iFlEf7xmSCSVZGw436V6HWmm9Ek", "1000", tags)
+		tx := transaction.New(data, "Cbj95zDZBBhmyht6iFlEf7xmSCSVZGw436V6HWmm9Ek", currency.NewWinston(1000), tags)
 		assert.NoError(t, err)
 
 		tx.Owner = s.Owner()
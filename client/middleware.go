@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Middleware wraps the transport of a Client, e.g. to trace requests.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to an http.RoundTripper.
+type RoundTripperFunc func(r *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(r).
+func (f RoundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// Use wraps the transport of the client with middlewares. The first
+// middleware is the outermost, so it sees each request first.
+//
+// Middlewares see every gateway request and can read its route template with
+// Route, which keeps span names and metric labels free of transaction IDs.
+//
+// Example:
+//
+//	c.Use(func(next http.RoundTripper) http.RoundTripper {
+//		return client.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+//			ctx, span := tracer.Start(r.Context(), client.Route(r))
+//			defer span.End()
+//			return next.RoundTrip(r.WithContext(ctx))
+//		})
+//	})
+func (c *Client) Use(middlewares ...Middleware) {
+	if c.Client == nil {
+		c.Client = &http.Client{}
+	}
+	transport := c.Client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		transport = middlewares[i](transport)
+	}
+	c.Client.Transport = transport
+}
+
+type routeKey struct{}
+
+// Route returns the route template of a request made by a Client, such as
+// "tx/{id}/status", or "" for other requests.
+func Route(r *http.Request) string {
+	route, _ := r.Context().Value(routeKey{}).(string)
+	return route
+}
+
+// withRoute attaches the route template to a request context.
+func withRoute(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, routeKey{}, routeTemplate(route))
+}
+
+// routeTemplate replaces the IDs, addresses and numbers in a route with
+// placeholders so routes can be used as low-cardinality labels.
+func routeTemplate(route string) string {
+	segments := strings.Split(strings.Trim(route, "/"), "/")
+	for i, s := range segments {
+		switch {
+		case len(s) == 43 && isBase64URL(s):
+			segments[i] = "{id}"
+		case s != "" && strings.Trim(s, "0123456789") == "":
+			segments[i] = "{n}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func isBase64URL(s string) bool {
+	for _, c := range s {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
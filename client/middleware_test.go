@@ -0,0 +1,96 @@
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/liteseed/goar/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRouteTemplate verifies IDs and numbers are replaced in route labels
+func TestRouteTemplate(t *testing.T) {
+	const id = "F7fmxSBJx5RlIRrt825iIEAL110cKP2Bf8tYd0Q1STU"
+	for route, expected := range map[string]string{
+		"info":                      "info",
+		"tx/" + id + "/status":      "tx/{id}/status",
+		id:                          "{id}",
+		"price/1024/" + id:          "price/{n}/{id}",
+		"wallet/" + id + "/balance": "wallet/{id}/balance",
+		"block/hash/" + id[:40]:     "block/hash/" + id[:40],
+		"/tx/" + id + "/data.html/": "tx/{id}/data.html",
+	} {
+		assert.Equal(t, expected, routeTemplate(route), route)
+	}
+}
+
+// TestMetrics verifies requests are counted and timed by gateway, route and status
+func TestMetrics(t *testing.T) {
+	c := newFakeNode(t, &fakeNode{height: 100})
+	m := metrics.NewMemory()
+	c.Metrics = m
+	u, err := url.Parse(c.Gateway)
+	require.NoError(t, err)
+
+	_, err = c.GetNetworkInfo()
+	require.NoError(t, err)
+	_, err = c.GetNetworkInfo()
+	require.NoError(t, err)
+	_, err = c.GetTransactionStatus("F7fmxSBJx5RlIRrt825iIEAL110cKP2Bf8tYd0Q1STU")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = c.post("chunk", []byte(`{"chunk":""}`))
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	labels := func(route string, method string, status string) []metrics.Label {
+		return []metrics.Label{
+			{Name: "gateway", Value: u.Host},
+			{Name: "route", Value: route},
+			{Name: "method", Value: method},
+			{Name: "status", Value: status},
+		}
+	}
+	assert.Equal(t, 2.0, m.Count(metrics.HTTP_REQUESTS, labels("info", "GET", "200")...))
+	assert.Len(t, m.Observations(metrics.HTTP_REQUEST_DURATION, labels("info", "GET", "200")...), 2)
+	assert.Equal(t, 1.0, m.Count(metrics.HTTP_REQUESTS, labels("tx/{id}/status", "GET", "404")...))
+	assert.Equal(t, 12.0, m.Count(metrics.UPLOADED_BYTES, metrics.Label{Name: "gateway", Value: u.Host}, metrics.Label{Name: "route", Value: "chunk"}))
+
+	// Transport errors are recorded with an error status
+	c.Gateway = "http://127.0.0.1:0"
+	_, err = c.GetNetworkInfo()
+	assert.Error(t, err)
+	assert.Equal(t, 1.0, m.Count(metrics.HTTP_REQUESTS, []metrics.Label{
+		{Name: "gateway", Value: "127.0.0.1:0"},
+		{Name: "route", Value: "info"},
+		{Name: "method", Value: "GET"},
+		{Name: "status", Value: "error"},
+	}...))
+}
+
+// TestUse verifies middlewares wrap the transport in order and see the route
+func TestUse(t *testing.T) {
+	c := newFakeNode(t, &fakeNode{height: 100})
+
+	var calls []string
+	middleware := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" "+Route(r))
+				return next.RoundTrip(r)
+			})
+		}
+	}
+	c.Use(middleware("outer"), middleware("inner"))
+
+	_, err := c.GetNetworkInfo()
+	require.NoError(t, err)
+	_, err = c.GetTransactionStatus("F7fmxSBJx5RlIRrt825iIEAL110cKP2Bf8tYd0Q1STU")
+	assert.Error(t, err)
+	assert.Equal(t, []string{"outer info", "inner info", "outer tx/{id}/status", "inner tx/{id}/status"}, calls)
+
+	// Requests not made by a Client have no route
+	r, err := http.NewRequest(http.MethodGet, c.Gateway, nil)
+	require.NoError(t, err)
+	assert.Empty(t, Route(r))
+}
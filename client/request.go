@@ -2,55 +2,114 @@ package client
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
 	"path"
+	"strconv"
+	"time"
+
+	"github.com/liteseed/goar/metrics"
 )
 
+// ErrNotFound is returned when the gateway responds with 404 Not Found.
+var ErrNotFound = errors.New("not found")
+
 func (c *Client) get(route string) ([]byte, error) {
-	u, err := url.Parse(c.Gateway)
+	return c.getContext(context.Background(), route)
+}
+
+func (c *Client) getContext(ctx context.Context, route string) ([]byte, error) {
+	code, body, err := c.do(ctx, http.MethodGet, route, nil)
 	if err != nil {
 		return nil, err
 	}
-
-	u.Path = path.Join(u.Path, route)
-
-	resp, err := c.Client.Get(u.String())
-	if err != nil {
+	if err = statusError(code, body); err != nil {
 		return nil, err
 	}
+	return body, nil
+}
 
-	body, err := io.ReadAll(resp.Body)
+func (c *Client) post(route string, payload []byte) (int, error) {
+	return c.postContext(context.Background(), route, payload)
+}
+
+func (c *Client) postContext(ctx context.Context, route string, payload []byte) (int, error) {
+	code, body, err := c.do(ctx, http.MethodPost, route, payload)
 	if err != nil {
-		return nil, err
+		return -1, err
 	}
-
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("%d: %s", resp.StatusCode, string(body))
+	if err = statusError(code, body); err != nil {
+		return code, err
 	}
-	return body, nil
+	return code, nil
 }
 
-func (c *Client) post(route string, payload []byte) (int, error) {
+// do sends a request to the gateway and returns the status code and body.
+//
+// Unlike get and post, HTTP error statuses are not turned into errors.
+func (c *Client) do(ctx context.Context, method string, route string, payload []byte) (int, []byte, error) {
 	u, err := url.Parse(c.Gateway)
 	if err != nil {
-		return -1, err
+		return -1, nil, err
 	}
 
 	u.Path = path.Join(u.Path, route)
-	resp, err := c.Client.Post(u.String(), "application/json", bytes.NewBuffer(payload))
+
+	var reqBody io.Reader
+	if payload != nil {
+		reqBody = bytes.NewBuffer(payload)
+	}
+	req, err := http.NewRequestWithContext(withRoute(ctx, route), method, u.String(), reqBody)
 	if err != nil {
-		return -1, err
+		return -1, nil, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	start := time.Now()
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		c.record(req, "error", start)
+		c.logger().DebugContext(ctx, "http request failed", "method", method, "url", u.String(), "duration", time.Since(start), "error", err)
+		return -1, nil, err
 	}
+	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return -1, err
+		c.record(req, "error", start)
+		c.logger().DebugContext(ctx, "http response failed", "method", method, "url", u.String(), "status", resp.StatusCode, "duration", time.Since(start), "error", err)
+		return -1, nil, err
+	}
+	c.record(req, strconv.Itoa(resp.StatusCode), start)
+	c.logger().DebugContext(ctx, "http request", "method", method, "url", u.String(), "status", resp.StatusCode, "duration", time.Since(start), "request_bytes", len(payload), "response_bytes", len(body))
+	return resp.StatusCode, body, nil
+}
+
+// record records the count, latency and uploaded bytes of a request.
+func (c *Client) record(req *http.Request, status string, start time.Time) {
+	gateway := metrics.Label{Name: "gateway", Value: req.URL.Host}
+	route := metrics.Label{Name: "route", Value: Route(req)}
+	labels := []metrics.Label{gateway, route, {Name: "method", Value: req.Method}, {Name: "status", Value: status}}
+	m := c.recorder()
+	m.Add(metrics.HTTP_REQUESTS, 1, labels...)
+	metrics.Since(m, metrics.HTTP_REQUEST_DURATION, start, labels...)
+	if req.ContentLength > 0 {
+		m.Add(metrics.UPLOADED_BYTES, float64(req.ContentLength), gateway, route)
+	}
+}
+
+// statusError converts HTTP error statuses into errors.
+func statusError(code int, body []byte) error {
+	if code == http.StatusNotFound {
+		return fmt.Errorf("%w - %d: %s", ErrNotFound, code, string(body))
 	}
-	code := resp.StatusCode
 	if code >= 400 {
-		return resp.StatusCode, fmt.Errorf("%d: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("%d: %s", code, string(body))
 	}
-	return code, nil
+	return nil
 }
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLogger verifies HTTP calls are logged at debug level only when a logger is set
+func TestLogger(t *testing.T) {
+	c := newFakeNode(t, &fakeNode{height: 100})
+
+	// Silent by default
+	_, err := c.GetNetworkInfo()
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	c.Logger = slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	_, err = c.GetNetworkInfo()
+	require.NoError(t, err)
+	_, err = c.GetBlockByID("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	logs := buf.String()
+	assert.Contains(t, logs, `level=DEBUG msg="http request" method=GET url=`+c.Gateway+"/info status=200")
+	assert.Contains(t, logs, "/block/hash/missing status=404")
+
+	// Nothing is logged above debug level
+	buf.Reset()
+	c.Logger = slog.New(slog.NewTextHandler(&buf, nil))
+	_, err = c.GetNetworkInfo()
+	require.NoError(t, err)
+	assert.Empty(t, buf.String())
+}
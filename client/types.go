@@ -1,6 +1,9 @@
 package client
 
-import "github.com/liteseed/goar/tag"
+import (
+	"github.com/liteseed/goar/currency"
+	"github.com/liteseed/goar/tag"
+)
 
 // Block represents a block in the Arweave blockchain.
 //
@@ -8,25 +11,29 @@ import "github.com/liteseed/goar/tag"
 // its hash, height, transactions, and mining-related data. Blocks
 // are the fundamental units of the Arweave blockchain that contain
 // batches of transactions.
+//
+// Gateways encode weave_size, block_size and reward_pool as JSON strings,
+// since they can exceed the integers JSON parsers handle exactly, so those
+// fields are decoded from strings.
 type Block struct {
-	Nonce          string    `json:"nonce"`            // Mining nonce used to find the block
-	PreviousBlock  string    `json:"previous_block"`   // Hash of the previous block
-	Timestamp      uint64    `json:"timestamp"`        // Unix timestamp when block was mined
-	LastRetarget   uint64    `json:"last_retarget"`    // Timestamp of last difficulty retarget
-	Diff           string    `json:"diff"`             // Current mining difficulty
-	Height         uint64    `json:"height"`           // Block height (number of blocks since genesis)
-	Hash           string    `json:"hash"`             // Block hash (dependent on transaction order)
-	IndepHash      string    `json:"indep_hash"`       // Independent hash (does not depend on transaction order)
-	Txs            []string  `json:"txs"`              // List of transaction IDs in this block
-	TxRoot         string    `json:"tx_root"`          // Merkle root of transaction tree
-	WalletList     string    `json:"wallet_list"`      // Hash of wallet list at this block
-	RewardAddr     string    `json:"reward_addr"`      // Address that will receive mining reward
-	Tags           []tag.Tag `json:"tags"`             // Optional tags attached to the block
-	RewardPool     uint64    `json:"reward_pool"`      // Current size of mining reward pool
-	WeaveSize      uint64    `json:"weave_size"`       // Total size of data stored in Arweave
-	BlockSize      uint64    `json:"block_size"`       // Size of this block in bytes
-	CumulativeDiff string    `json:"cumulative_diff"`  // Cumulative difficulty since genesis
-	HashListMerkle string    `json:"hash_list_merkle"` // Merkle root of block hash list
+	Nonce          string           `json:"nonce"`             // Mining nonce used to find the block
+	PreviousBlock  string           `json:"previous_block"`    // Hash of the previous block
+	Timestamp      uint64           `json:"timestamp"`         // Unix timestamp when block was mined
+	LastRetarget   uint64           `json:"last_retarget"`     // Timestamp of last difficulty retarget
+	Diff           string           `json:"diff"`              // Current mining difficulty
+	Height         uint64           `json:"height"`            // Block height (number of blocks since genesis)
+	Hash           string           `json:"hash"`              // Block hash (dependent on transaction order)
+	IndepHash      string           `json:"indep_hash"`        // Independent hash (does not depend on transaction order)
+	Txs            []string         `json:"txs"`               // List of transaction IDs in this block
+	TxRoot         string           `json:"tx_root"`           // Merkle root of transaction tree
+	WalletList     string           `json:"wallet_list"`       // Hash of wallet list at this block
+	RewardAddr     string           `json:"reward_addr"`       // Address that will receive mining reward
+	Tags           []tag.Tag        `json:"tags"`              // Optional tags attached to the block
+	RewardPool     currency.Winston `json:"reward_pool"`       // Current size of mining reward pool
+	WeaveSize      uint64           `json:"weave_size,string"` // Total size of data stored in Arweave
+	BlockSize      uint64           `json:"block_size,string"` // Size of this block in bytes
+	CumulativeDiff string           `json:"cumulative_diff"`   // Cumulative difficulty since genesis
+	HashListMerkle string           `json:"hash_list_merkle"`  // Merkle root of block hash list
 }
 
 // NetworkInfo represents current information about the Arweave network.
@@ -55,5 +62,5 @@ type TransactionStatus struct {
 	BlockHeight           int    `json:"block_height"`            // Height of block containing this transaction (0 if unconfirmed)
 	BlockIndepHash        string `json:"block_indep_hash"`        // Independent hash of block containing this transaction
 	NumberOfConfirmations int    `json:"number_of_confirmations"` // Number of confirmations (blocks since inclusion)
-	Confirmed             bool   `json:"-"`                       // Whether the transaction is confirmed (derived field)
+	Confirmed             bool   `json:"-"`                       // Whether the transaction is mined in a block (derived field)
 }
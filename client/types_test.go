@@ -0,0 +1,31 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBlockJSON verifies blocks decode from the gateway layout, where sizes are strings
+func TestBlockJSON(t *testing.T) {
+	b := &Block{}
+	err := json.Unmarshal([]byte(`{
+		"indep_hash": "hash",
+		"height": 1000000,
+		"timestamp": 1663596544,
+		"weave_size": "123456789012345",
+		"block_size": "2097152",
+		"reward_pool": "123456789012345678901"
+	}`), b)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1000000), b.Height)
+	assert.Equal(t, uint64(123456789012345), b.WeaveSize)
+	assert.Equal(t, uint64(2097152), b.BlockSize)
+	assert.Equal(t, "123456789012345678901", b.RewardPool.String())
+
+	// Numeric sizes are not the gateway layout
+	err = json.Unmarshal([]byte(`{"weave_size": 1024}`), &Block{})
+	assert.Error(t, err)
+}
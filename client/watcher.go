@@ -0,0 +1,341 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// MAX_ANCHOR_DEPTH is the number of blocks after its anchor block during
+// which a transaction can still be mined.
+const MAX_ANCHOR_DEPTH = 50
+
+// Default polling intervals of a Watcher.
+const (
+	DEFAULT_MIN_POLL_INTERVAL = 5 * time.Second
+	DEFAULT_MAX_POLL_INTERVAL = 2 * time.Minute
+)
+
+// ErrDropped is returned by WaitForConfirmation when a transaction can no
+// longer be mined because its anchor expired.
+var ErrDropped = errors.New("transaction dropped")
+
+// State is the lifecycle state of a watched transaction.
+type State int
+
+// Transaction states reported by a Watcher.
+const (
+	StatePending   State = iota // Not mined yet, either in the mempool or not seen by the gateway
+	StateMined                  // Mined with fewer than the required confirmations
+	StateConfirmed              // Mined with at least the required confirmations
+	StateDropped                // Anchor expired before the transaction was mined
+)
+
+// String returns the name of the state.
+func (s State) String() string {
+	switch s {
+	case StatePending:
+		return "pending"
+	case StateMined:
+		return "mined"
+	case StateConfirmed:
+		return "confirmed"
+	case StateDropped:
+		return "dropped"
+	}
+	return "unknown"
+}
+
+// Event reports a state change of a watched transaction.
+type Event struct {
+	ID            string             // ID of the transaction
+	State         State              // New state of the transaction
+	Confirmations int                // Number of confirmations (0 unless mined or confirmed)
+	Status        *TransactionStatus // Latest status from the gateway (nil if the transaction is not known)
+}
+
+// watched is the tracking state of a single transaction.
+type watched struct {
+	anchor   string        // Anchor of the transaction, used to find the expiry height
+	deadline int64         // Height after which the transaction is dropped (0 if unknown)
+	state    State         // Last reported state
+	reported bool          // Whether an event was emitted yet
+	conf     int           // Last reported confirmations
+	interval time.Duration // Current polling interval
+	next     time.Time     // Time of the next poll
+}
+
+// Watcher tracks the confirmation state of many transactions.
+//
+// Each transaction is polled on its own schedule: the interval starts at
+// MinInterval, doubles after every poll without a change up to MaxInterval,
+// and resets when the state changes. Transactions stop being tracked once
+// they are confirmed or dropped.
+//
+// A transaction is dropped when it is not mined and the network height is
+// more than MAX_ANCHOR_DEPTH blocks past its anchor block. When the anchor
+// is unknown or is not a block, the height at which the watcher first
+// polled the transaction is used instead, which can only detect drops later,
+// never earlier.
+//
+// Example:
+//
+//	w := c.NewWatcher(10)
+//	w.Add(tx.ID, tx.LastTx)
+//	go w.Run(ctx)
+//	for e := range w.Events() {
+//		fmt.Printf("%s: %s (%d)\n", e.ID, e.State, e.Confirmations)
+//	}
+type Watcher struct {
+	MinInterval time.Duration // Shortest polling interval (defaults to DEFAULT_MIN_POLL_INTERVAL)
+	MaxInterval time.Duration // Longest polling interval (defaults to DEFAULT_MAX_POLL_INTERVAL)
+
+	client           *Client
+	minConfirmations int
+	events           chan Event
+	wake             chan struct{}
+
+	mu  sync.Mutex
+	txs map[string]*watched
+}
+
+// NewWatcher creates a watcher that reports transactions as confirmed once
+// they have at least minConfirmations confirmations.
+//
+// Parameters:
+//   - minConfirmations: Required confirmations (values below 1 are treated as 1)
+//
+// Returns a Watcher; call Run to start polling.
+func (c *Client) NewWatcher(minConfirmations int) *Watcher {
+	if minConfirmations < 1 {
+		minConfirmations = 1
+	}
+	return &Watcher{
+		MinInterval:      DEFAULT_MIN_POLL_INTERVAL,
+		MaxInterval:      DEFAULT_MAX_POLL_INTERVAL,
+		client:           c,
+		minConfirmations: minConfirmations,
+		events:           make(chan Event, 64),
+		wake:             make(chan struct{}, 1),
+		txs:              map[string]*watched{},
+	}
+}
+
+// Add starts tracking a transaction.
+//
+// Adding a transaction that is already tracked has no effect.
+//
+// Parameters:
+//   - id: The transaction ID
+//   - anchor: The transaction anchor (LastTx), used to detect drops (can be empty)
+func (w *Watcher) Add(id string, anchor string) {
+	w.mu.Lock()
+	if _, ok := w.txs[id]; !ok {
+		w.txs[id] = &watched{anchor: anchor, interval: w.MinInterval, next: time.Now()}
+	}
+	w.mu.Unlock()
+
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Remove stops tracking a transaction.
+func (w *Watcher) Remove(id string) {
+	w.mu.Lock()
+	delete(w.txs, id)
+	w.mu.Unlock()
+}
+
+// Len returns the number of tracked transactions.
+func (w *Watcher) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.txs)
+}
+
+// Events returns the channel of state changes.
+//
+// The channel is closed when Run returns.
+func (w *Watcher) Events() <-chan Event {
+	return w.events
+}
+
+// Run polls the tracked transactions until ctx is done.
+//
+// Run must be called at most once. Events are delivered on the Events
+// channel, which must be drained; Run blocks while the channel is full.
+//
+// Returns the context error once ctx is done.
+func (w *Watcher) Run(ctx context.Context) error {
+	defer close(w.events)
+	for {
+		delay := w.poll(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-w.wake:
+		case <-time.After(delay):
+		}
+	}
+}
+
+// poll checks every transaction that is due and returns the delay until the
+// next transaction is due.
+func (w *Watcher) poll(ctx context.Context) time.Duration {
+	now := time.Now()
+	var due []string
+	w.mu.Lock()
+	for id, t := range w.txs {
+		if !t.next.After(now) {
+			due = append(due, id)
+		}
+	}
+	w.mu.Unlock()
+
+	if len(due) > 0 {
+		var height int64 = -1
+		if info, err := w.client.GetNetworkInfoContext(ctx); err == nil {
+			height = info.Height
+		}
+		for _, id := range due {
+			if ctx.Err() != nil {
+				break
+			}
+			w.check(ctx, id, height)
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delay := w.MaxInterval
+	for _, t := range w.txs {
+		if d := time.Until(t.next); d < delay {
+			delay = d
+		}
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	return delay
+}
+
+// check polls a single transaction and emits an event if its state changed.
+func (w *Watcher) check(ctx context.Context, id string, height int64) {
+	w.mu.Lock()
+	t, ok := w.txs[id]
+	w.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	status, err := w.client.GetTransactionStatusContext(ctx, id)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		// Transient error, try again later
+		w.reschedule(t, false)
+		return
+	}
+
+	e := Event{ID: id, State: StatePending, Status: status}
+	switch {
+	case status != nil && status.Confirmed:
+		e.Confirmations = status.NumberOfConfirmations
+		e.State = StateMined
+		if e.Confirmations >= w.minConfirmations {
+			e.State = StateConfirmed
+		}
+	case height >= 0:
+		if t.deadline == 0 {
+			t.deadline = w.deadline(ctx, t.anchor, height)
+		}
+		if height > t.deadline {
+			e.State = StateDropped
+		}
+	}
+
+	changed := !t.reported || t.state != e.State || t.conf != e.Confirmations
+	t.reported, t.state, t.conf = true, e.State, e.Confirmations
+	if e.State == StateConfirmed || e.State == StateDropped {
+		w.Remove(id)
+	} else {
+		w.reschedule(t, changed)
+	}
+	if changed {
+		select {
+		case w.events <- e:
+		case <-ctx.Done():
+		}
+	}
+}
+
+// deadline returns the last height at which a transaction with the given
+// anchor can be mined.
+func (w *Watcher) deadline(ctx context.Context, anchor string, height int64) int64 {
+	if anchor != "" {
+		if b, err := w.client.GetBlockByIDContext(ctx, anchor); err == nil {
+			return int64(b.Height) + MAX_ANCHOR_DEPTH
+		}
+	}
+	return height + MAX_ANCHOR_DEPTH
+}
+
+// reschedule sets the next poll time of a transaction, backing off while
+// nothing changes.
+func (w *Watcher) reschedule(t *watched, changed bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if changed {
+		t.interval = w.MinInterval
+	} else {
+		t.interval *= 2
+		if t.interval > w.MaxInterval {
+			t.interval = w.MaxInterval
+		}
+	}
+	t.next = time.Now().Add(t.interval)
+}
+
+// WaitForConfirmation blocks until a transaction has at least
+// minConfirmations confirmations.
+//
+// The anchor is used to detect a transaction that was dropped: once it has
+// expired, ErrDropped is returned instead of waiting for ctx to end.
+//
+// Parameters:
+//   - ctx: Context controlling how long to wait
+//   - id: The transaction ID
+//   - anchor: The transaction anchor (tx.LastTx), or "" to wait until ctx ends
+//   - minConfirmations: Required confirmations (values below 1 are treated as 1)
+//
+// Returns the status of the confirmed transaction, ErrDropped if the
+// transaction can no longer be mined, or the context error.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+//	defer cancel()
+//	status, err := client.WaitForConfirmation(ctx, tx.ID, tx.LastTx, 10)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Printf("Confirmed in block %s\n", status.BlockIndepHash)
+func (c *Client) WaitForConfirmation(ctx context.Context, id string, anchor string, minConfirmations int) (*TransactionStatus, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	w := c.NewWatcher(minConfirmations)
+	w.Add(id, anchor)
+	go w.Run(ctx)
+
+	for e := range w.Events() {
+		switch e.State {
+		case StateConfirmed:
+			return e.Status, nil
+		case StateDropped:
+			return nil, ErrDropped
+		}
+	}
+	return nil, ctx.Err()
+}
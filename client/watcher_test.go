@@ -0,0 +1,217 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNode serves the endpoints used by the watcher from mutable state.
+type fakeNode struct {
+	mu       sync.Mutex
+	height   int64
+	statuses map[string][]string // Responses returned in order, the last one is repeated
+	blocks   map[string]int64    // Block heights by indep hash
+}
+
+func (n *fakeNode) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var id string
+	switch {
+	case r.URL.Path == "/info":
+		fmt.Fprintf(rw, `{"network":"arweave.N.1","height":%d}`, n.height)
+	case sscan(r.URL.Path, "/block/hash/%s", &id):
+		h, ok := n.blocks[id]
+		if !ok {
+			http.NotFound(rw, r)
+			return
+		}
+		fmt.Fprintf(rw, `{"indep_hash":%q,"height":%d,"weave_size":"1024","block_size":"0","reward_pool":"123456789012345678901"}`, id, h)
+	case sscan(r.URL.Path, "/tx/%s", &id):
+		id = id[:len(id)-len("/status")]
+		responses := n.statuses[id]
+		if len(responses) == 0 {
+			http.NotFound(rw, r)
+			return
+		}
+		res := responses[0]
+		if len(responses) > 1 {
+			n.statuses[id] = responses[1:]
+		}
+		switch res {
+		case "404":
+			http.NotFound(rw, r)
+		case "202":
+			rw.WriteHeader(http.StatusAccepted)
+			_, _ = rw.Write([]byte("Pending"))
+		default:
+			fmt.Fprintf(rw, `{"block_height":100,"block_indep_hash":"block","number_of_confirmations":%s}`, res)
+		}
+	default:
+		http.NotFound(rw, r)
+	}
+}
+
+func sscan(path string, format string, id *string) bool {
+	n, _ := fmt.Sscanf(path, format, id)
+	return n == 1
+}
+
+func newFakeNode(t *testing.T, n *fakeNode) *Client {
+	s := httptest.NewServer(n)
+	t.Cleanup(s.Close)
+	return New(s.URL)
+}
+
+func TestGetTransactionStatusStates(t *testing.T) {
+	c := newFakeNode(t, &fakeNode{statuses: map[string][]string{
+		"pending": {"202"},
+		"mined":   {"3"},
+	}})
+
+	status, err := c.GetTransactionStatus("pending")
+	require.NoError(t, err)
+	assert.False(t, status.Confirmed)
+
+	status, err = c.GetTransactionStatus("mined")
+	require.NoError(t, err)
+	assert.True(t, status.Confirmed)
+	assert.Equal(t, 3, status.NumberOfConfirmations)
+	assert.Equal(t, "block", status.BlockIndepHash)
+
+	_, err = c.GetTransactionStatus("unknown")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGetBlockByID(t *testing.T) {
+	c := newFakeNode(t, &fakeNode{blocks: map[string]int64{"anchor": 42}})
+
+	b, err := c.GetBlockByID("anchor")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), b.Height)
+	assert.Equal(t, uint64(1024), b.WeaveSize)
+	assert.Equal(t, "123456789012345678901", b.RewardPool.String())
+}
+
+func TestWatcher(t *testing.T) {
+	n := &fakeNode{
+		height: 110,
+		statuses: map[string][]string{
+			"confirmed": {"404", "202", "202", "1", "1", "2", "3"},
+			"dropped":   {"202"},
+		},
+		blocks: map[string]int64{"anchor": 60},
+	}
+	c := newFakeNode(t, n)
+
+	w := c.NewWatcher(3)
+	w.MinInterval = time.Millisecond
+	w.MaxInterval = 5 * time.Millisecond
+	w.Add("confirmed", "anchor")
+	// Anchored at 60, the transaction is still valid at 110 but not at 111
+	w.Add("dropped", "anchor")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	go w.Run(ctx)
+
+	var events []Event
+	for e := range w.Events() {
+		events = append(events, e)
+		if e.State == StateConfirmed {
+			cancel()
+		}
+	}
+
+	var confirmed, dropped []string
+	for _, e := range events {
+		s := fmt.Sprintf("%s(%d)", e.State, e.Confirmations)
+		if e.ID == "confirmed" {
+			confirmed = append(confirmed, s)
+		} else {
+			dropped = append(dropped, s)
+		}
+	}
+	assert.Equal(t, []string{"pending(0)", "mined(1)", "mined(2)", "confirmed(3)"}, confirmed)
+	assert.Equal(t, []string{"pending(0)"}, dropped)
+
+	// Expire the anchor of the remaining transaction
+	n.mu.Lock()
+	n.height = 111
+	n.mu.Unlock()
+
+	w = c.NewWatcher(1)
+	w.MinInterval = time.Millisecond
+	w.Add("dropped", "anchor")
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	go w.Run(ctx)
+	e := <-w.Events()
+	assert.Equal(t, Event{ID: "dropped", State: StateDropped, Status: &TransactionStatus{}}, e)
+	assert.Equal(t, 0, w.Len())
+}
+
+func TestWatcherUnknownAnchor(t *testing.T) {
+	n := &fakeNode{height: 200, statuses: map[string][]string{}}
+	c := newFakeNode(t, n)
+
+	w := c.NewWatcher(1)
+	w.MinInterval = time.Millisecond
+	w.MaxInterval = time.Millisecond
+	w.Add("missing", "not-a-block")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	go w.Run(ctx)
+
+	e := <-w.Events()
+	assert.Equal(t, StatePending, e.State)
+	assert.Nil(t, e.Status)
+
+	// The first seen height is used when the anchor block is unknown
+	n.mu.Lock()
+	n.height = 251
+	n.mu.Unlock()
+	e = <-w.Events()
+	assert.Equal(t, StateDropped, e.State)
+}
+
+func TestWaitForConfirmation(t *testing.T) {
+	c := newFakeNode(t, &fakeNode{height: 100, statuses: map[string][]string{
+		"mined":   {"5"},
+		"pending": {"202"},
+	}})
+
+	status, err := c.WaitForConfirmation(context.Background(), "mined", "anchor", 5)
+	require.NoError(t, err)
+	assert.Equal(t, 5, status.NumberOfConfirmations)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = c.WaitForConfirmation(ctx, "pending", "anchor", 1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// A transaction whose anchor expired is reported as dropped without waiting for ctx
+	c = newFakeNode(t, &fakeNode{height: 100 + MAX_ANCHOR_DEPTH + 1, statuses: map[string][]string{}, blocks: map[string]int64{"anchor": 100}})
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = c.WaitForConfirmation(ctx, "dropped", "anchor", 1)
+	assert.ErrorIs(t, err, ErrDropped)
+}
+
+func TestStateString(t *testing.T) {
+	assert.Equal(t, "pending", StatePending.String())
+	assert.Equal(t, "mined", StateMined.String())
+	assert.Equal(t, "confirmed", StateConfirmed.String())
+	assert.Equal(t, "dropped", StateDropped.String())
+	assert.Equal(t, "unknown", State(42).String())
+}
@@ -1,49 +1,58 @@
 package crypto
 
 import (
-	"crypto/sha512"
 	"fmt"
 	"reflect"
+
+	"github.com/liteseed/goar/crypto/deephash"
 )
 
 // DeepHash is a hash algorithm which takes a nested list of values as input
 // and produces a 384 bit hash, where a change of any value or the structure
 // will affect the hash.
 // https://www.arweave.org/yellow-paper.pdf
+//
+// []byte values are blobs; [][]byte and []any values are lists. Any other
+// value panics, including deephash chunks: use deephash.Hash to hash them,
+// which returns the error of a blob that cannot be read.
 func DeepHash(data any) [48]byte {
-	if typeof(data) == "[]uint8" {
-		tag := append([]byte("blob"), []byte(fmt.Sprint(len(data.([]byte))))...)
-		tagHashed := sha512.Sum384(tag)
-		dataHashed := sha512.Sum384(data.([]byte))
-		r := append(tagHashed[:], dataHashed[:]...)
-		rHashed := sha512.Sum384(r)
-		return rHashed
-	} else {
-		d := unpackArray(data)
-		tag := append([]byte("list"), []byte(fmt.Sprint(len(d)))...)
-		return deepHashChunk(d, sha512.Sum384(tag))
+	h, err := deephash.Hash(toChunk(data))
+	if err != nil {
+		// In-memory chunks cannot fail, and readers are rejected by toChunk
+		panic(err)
 	}
+	return h
 }
 
-func deepHashChunk(data []any, acc [48]byte) [48]byte {
-	if len(data) < 1 {
-		return acc
+// toChunk converts a nested value to a deep hash chunk.
+func toChunk(data any) deephash.Chunk {
+	switch v := data.(type) {
+	case []byte:
+		return deephash.Bytes(v)
+	case [][]byte:
+		chunks := make([]deephash.Chunk, len(v))
+		for i, b := range v {
+			chunks[i] = deephash.Bytes(b)
+		}
+		return deephash.List(chunks...)
+	case []any:
+		chunks := make([]deephash.Chunk, len(v))
+		for i, c := range v {
+			chunks[i] = toChunk(c)
+		}
+		return deephash.List(chunks...)
+	case deephash.Chunk:
+		panic(fmt.Sprintf("deep hash: %T must be hashed with deephash.Hash", data))
 	}
-	dHash := DeepHash(data[0])
-	hashPair := append(acc[:], dHash[:]...)
-	newAcc := sha512.Sum384(hashPair)
-	return deepHashChunk(data[1:], newAcc)
-}
 
-func typeof(v any) string {
-	return reflect.TypeOf(v).String()
-}
-
-func unpackArray(s any) []any {
-	v := reflect.ValueOf(s)
-	r := make([]any, v.Len())
-	for i := 0; i < v.Len(); i++ {
-		r[i] = v.Index(i).Interface()
+	// Other slice types, such as [][][]byte, fall back to reflection
+	rv := reflect.ValueOf(data)
+	if rv.Kind() != reflect.Slice {
+		panic(fmt.Sprintf("deep hash: unsupported type %T", data))
+	}
+	chunks := make([]deephash.Chunk, rv.Len())
+	for i := range chunks {
+		chunks[i] = toChunk(rv.Index(i).Interface())
 	}
-	return r
+	return deephash.List(chunks...)
 }
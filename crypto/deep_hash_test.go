@@ -1,9 +1,11 @@
 package crypto
 
 import (
+	"bytes"
 	"encoding/hex"
 	"testing"
 
+	"github.com/liteseed/goar/crypto/deephash"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -118,3 +120,16 @@ func TestDeepHash(t *testing.T) {
 
 	})
 }
+
+// TestDeepHashRejectsChunks verifies deephash chunks are not hashed, as their
+// readers can fail
+func TestDeepHashRejectsChunks(t *testing.T) {
+	tests := []any{
+		deephash.Blob(bytes.NewReader([]byte("short")), 10),
+		deephash.Bytes([]byte("data")),
+		[]any{[]byte("dataitem"), deephash.List()},
+	}
+	for _, data := range tests {
+		assert.Panics(t, func() { DeepHash(data) })
+	}
+}
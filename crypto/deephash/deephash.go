@@ -0,0 +1,126 @@
+// Package deephash implements the Arweave deep hash without reflection and
+// with streaming blobs.
+//
+// A deep hash is a SHA-384 hash over a tree of blobs and lists, where a change
+// to any value or to the structure changes the hash. Transactions and data
+// items are signed over the deep hash of their fields.
+//
+// The tree is built with Bytes, Blob and List. Blob reads its content from an
+// io.Reader while hashing, so a multi-gigabyte payload is hashed without being
+// held in memory. List children are folded iteratively.
+//
+// Example usage:
+//
+//	f, err := os.Open("video.mp4")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer f.Close()
+//	info, _ := f.Stat()
+//
+//	h, err := deephash.Hash(deephash.List(
+//		deephash.Bytes([]byte("dataitem")),
+//		deephash.Bytes([]byte("1")),
+//		deephash.Blob(f, info.Size()),
+//	))
+package deephash
+
+import (
+	"crypto/sha512"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Size is the size of a deep hash in bytes.
+const Size = sha512.Size384
+
+// Chunk is a node of a deep hash tree: a blob or a list.
+type Chunk interface {
+	hash() ([Size]byte, error)
+}
+
+// blob is a chunk whose content is read from a reader.
+type blob struct {
+	r    io.Reader
+	size int64
+}
+
+// bytesBlob is a chunk whose content is in memory.
+type bytesBlob []byte
+
+// list is a chunk made of other chunks.
+type list []Chunk
+
+// Bytes returns a blob chunk with in-memory content.
+func Bytes(b []byte) Chunk {
+	return bytesBlob(b)
+}
+
+// Blob returns a blob chunk whose content is streamed from r.
+//
+// The size is part of the hash and must be known up front. Hashing fails if r
+// returns fewer or more than size bytes. r is consumed by Hash, so a chunk
+// made with Blob can only be hashed once.
+func Blob(r io.Reader, size int64) Chunk {
+	return &blob{r: r, size: size}
+}
+
+// List returns a list chunk.
+func List(chunks ...Chunk) Chunk {
+	return list(chunks)
+}
+
+// Hash returns the deep hash of a chunk.
+//
+// Returns the hash, or an error if a blob cannot be read or its reader does
+// not return exactly the declared size.
+func Hash(c Chunk) ([Size]byte, error) {
+	return c.hash()
+}
+
+func (b bytesBlob) hash() ([Size]byte, error) {
+	content := sha512.Sum384(b)
+	return blobHash(int64(len(b)), content[:]), nil
+}
+
+func (b *blob) hash() ([Size]byte, error) {
+	if b.size < 0 {
+		return [Size]byte{}, fmt.Errorf("deephash: invalid blob size %d", b.size)
+	}
+	h := sha512.New384()
+	// Read one byte more than declared to detect readers that are too long
+	n, err := io.Copy(h, io.LimitReader(b.r, b.size+1))
+	if err != nil {
+		return [Size]byte{}, err
+	}
+	if n < b.size {
+		return [Size]byte{}, fmt.Errorf("deephash: blob is shorter than its declared size: read %d of %d bytes", n, b.size)
+	}
+	if n > b.size {
+		return [Size]byte{}, fmt.Errorf("deephash: blob is longer than its declared size of %d bytes", b.size)
+	}
+	return blobHash(b.size, h.Sum(nil)), nil
+}
+
+func (l list) hash() ([Size]byte, error) {
+	acc := sha512.Sum384([]byte("list" + strconv.Itoa(len(l))))
+	pair := make([]byte, 0, 2*Size)
+	for _, c := range l {
+		h, err := c.hash()
+		if err != nil {
+			return [Size]byte{}, err
+		}
+		pair = append(append(pair[:0], acc[:]...), h[:]...)
+		acc = sha512.Sum384(pair)
+	}
+	return acc, nil
+}
+
+// blobHash combines the size tag and content hash of a blob.
+func blobHash(size int64, content []byte) [Size]byte {
+	tag := sha512.Sum384([]byte("blob" + strconv.FormatInt(size, 10)))
+	pair := make([]byte, 0, 2*Size)
+	pair = append(append(pair, tag[:]...), content...)
+	return sha512.Sum384(pair)
+}
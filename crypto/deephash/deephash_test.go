@@ -0,0 +1,196 @@
+package deephash_test
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/crypto/deephash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encode(h [deephash.Size]byte) string {
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
+
+// TestVectors verifies the vectors of crypto/deep_hash_test.go with the builder
+func TestVectors(t *testing.T) {
+	seven := []byte{1, 2, 3, 4, 5, 6, 7}
+	tests := []struct {
+		name     string
+		chunk    deephash.Chunk
+		expected string
+	}{
+		{"empty blob", deephash.Bytes(nil), "-_AMxET1_qncO-32KhP7qK6H50RfyRBWeiO-xOuC-tsRQ8QzBpMU2DYpg9w8Lko4"},
+		{"list of one blob", deephash.List(deephash.Bytes([]byte{1, 2, 3})), "48RIKS3zEOKqJgEe5CdqiAQ9xh1L4m_dFcy1deagU5VSF9HXcLN03FPBKAky1QEk"},
+		{"blob", deephash.Bytes(seven), "g2o7Doi92hekMqQoyUDTmdHhTPnLtOth_jClkUmTg1Xqfmhihe6g2Hkxejm9q5uN"},
+		{"list", deephash.List(deephash.Bytes(seven), deephash.Bytes(nil), deephash.Bytes(seven), deephash.Bytes(seven), deephash.Bytes([]byte{1, 2, 3})), "9Tixlu4upVVHyG2EKfH813A4CQJs-3ER30CLeC13nsu6pJ8FtJv2hVJwGuxMZZp0"},
+		{"list of zero byte", deephash.List(deephash.Bytes([]byte{0})), "MIPBvgxCUbtsISBhPBxudGTcoY-rb4126Sh_Viw6UuBEatXtbd_3DtmzClY3dSxC"},
+		{"list of empty blob", deephash.List(deephash.Bytes([]byte{})), "u_5kojLZOEwkWFm_IWBZj3H4Siy7Ube_IHQ24xCtpQksil7tglh4W8BIHE3aCEOK"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := deephash.Hash(tt.chunk)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, encode(h))
+		})
+	}
+}
+
+// TestBlobMatchesBytes verifies streamed blobs hash like in-memory blobs
+func TestBlobMatchesBytes(t *testing.T) {
+	for _, size := range []int{0, 1, 1000, 1 << 20} {
+		data := bytes.Repeat([]byte{7}, size)
+		expected, err := deephash.Hash(deephash.List(deephash.Bytes([]byte("x")), deephash.Bytes(data)))
+		require.NoError(t, err)
+
+		streamed, err := deephash.Hash(deephash.List(deephash.Bytes([]byte("x")), deephash.Blob(bytes.NewReader(data), int64(size))))
+		require.NoError(t, err)
+		assert.Equal(t, expected, streamed, size)
+	}
+}
+
+// TestBlobSizeMismatch verifies readers shorter or longer than declared are rejected
+func TestBlobSizeMismatch(t *testing.T) {
+	_, err := deephash.Hash(deephash.Blob(bytes.NewReader(make([]byte, 10)), 11))
+	assert.ErrorContains(t, err, "shorter")
+
+	_, err = deephash.Hash(deephash.Blob(bytes.NewReader(make([]byte, 10)), 9))
+	assert.ErrorContains(t, err, "longer")
+
+	_, err = deephash.Hash(deephash.Blob(bytes.NewReader(nil), -1))
+	assert.Error(t, err)
+}
+
+// TestBlobReadError verifies reader errors are returned
+func TestBlobReadError(t *testing.T) {
+	r := io.MultiReader(bytes.NewReader(make([]byte, 5)), &failingReader{})
+	_, err := deephash.Hash(deephash.List(deephash.Blob(r, 10)))
+	assert.ErrorIs(t, err, errRead)
+}
+
+// TestMatchesLegacy verifies nested structures hash like the reflection-based implementation
+func TestMatchesLegacy(t *testing.T) {
+	data := []any{
+		[]byte("dataitem"),
+		[]byte("1"),
+		[][]byte{{1, 2}, {}, {3}},
+		[]any{[]byte{4}, []any{[]byte{5}, [][]byte{}}},
+		bytes.Repeat([]byte{9}, 5000),
+	}
+	expected := legacyDeepHash(data)
+	assert.Equal(t, expected, crypto.DeepHash(data))
+}
+
+// TestLongList verifies long lists do not need one stack frame per element
+func TestLongList(t *testing.T) {
+	chunks := make([]deephash.Chunk, 1_000_000)
+	for i := range chunks {
+		chunks[i] = deephash.Bytes(nil)
+	}
+	_, err := deephash.Hash(deephash.List(chunks...))
+	assert.NoError(t, err)
+}
+
+var errRead = fmt.Errorf("read failed")
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errRead }
+
+// zeroReader returns zero bytes forever
+type zeroReader struct{}
+
+func (zeroReader) Read(b []byte) (int, error) {
+	clear(b)
+	return len(b), nil
+}
+
+// BenchmarkBlobStream hashes a 64 MiB blob streamed from a reader without buffering it
+func BenchmarkBlobStream(b *testing.B) {
+	const size = 64 << 20
+	b.SetBytes(size)
+	for i := 0; i < b.N; i++ {
+		if _, err := deephash.Hash(deephash.List(deephash.Bytes([]byte("dataitem")), deephash.Blob(io.LimitReader(zeroReader{}, size), size))); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkBytes hashes a 64 MiB in-memory blob with the builder
+func BenchmarkBytes(b *testing.B) {
+	data := make([]byte, 64<<20)
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		if _, err := deephash.Hash(deephash.List(deephash.Bytes([]byte("dataitem")), deephash.Bytes(data))); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkLegacyBytes hashes the same blob with the reflection-based implementation
+func BenchmarkLegacyBytes(b *testing.B) {
+	data := make([]byte, 64<<20)
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		legacyDeepHash([]any{[]byte("dataitem"), data})
+	}
+}
+
+// BenchmarkList hashes a list of 10000 small blobs with the builder
+func BenchmarkList(b *testing.B) {
+	chunks := make([]deephash.Chunk, 10000)
+	for i := range chunks {
+		chunks[i] = deephash.Bytes([]byte{byte(i)})
+	}
+	for i := 0; i < b.N; i++ {
+		if _, err := deephash.Hash(deephash.List(chunks...)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkLegacyList hashes the same list with the reflection-based implementation
+func BenchmarkLegacyList(b *testing.B) {
+	data := make([][]byte, 10000)
+	for i := range data {
+		data[i] = []byte{byte(i)}
+	}
+	for i := 0; i < b.N; i++ {
+		legacyDeepHash(data)
+	}
+}
+
+// legacyDeepHash is the previous reflection-based and recursive implementation
+// of crypto.DeepHash, kept as a reference for equivalence and benchmarks.
+func legacyDeepHash(data any) [48]byte {
+	if reflect.TypeOf(data).String() == "[]uint8" {
+		tag := append([]byte("blob"), []byte(fmt.Sprint(len(data.([]byte))))...)
+		tagHashed := sha512.Sum384(tag)
+		dataHashed := sha512.Sum384(data.([]byte))
+		r := append(tagHashed[:], dataHashed[:]...)
+		return sha512.Sum384(r)
+	}
+	v := reflect.ValueOf(data)
+	d := make([]any, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		d[i] = v.Index(i).Interface()
+	}
+	tag := append([]byte("list"), []byte(fmt.Sprint(len(d)))...)
+	return legacyDeepHashChunk(d, sha512.Sum384(tag))
+}
+
+func legacyDeepHashChunk(data []any, acc [48]byte) [48]byte {
+	if len(data) < 1 {
+		return acc
+	}
+	dHash := legacyDeepHash(data[0])
+	hashPair := append(acc[:], dHash[:]...)
+	return legacyDeepHashChunk(data[1:], sha512.Sum384(hashPair))
+}
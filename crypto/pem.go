@@ -0,0 +1,42 @@
+package crypto
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// GetPublicKeyFromPEM - Parse an RSA public key from a PEM "PUBLIC KEY" (SPKI) or "RSA PUBLIC KEY" (PKCS#1) block
+func GetPublicKeyFromPEM(b []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("invalid PEM - no block found")
+	}
+	switch block.Type {
+	case "PUBLIC KEY":
+		return GetPublicKeyFromSPKI(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+	return nil, fmt.Errorf("unsupported PEM block type: %q", block.Type)
+}
+
+// GetPublicKeyFromSPKI - Parse an RSA public key from a DER-encoded SubjectPublicKeyInfo
+func GetPublicKeyFromSPKI(der []byte) (*rsa.PublicKey, error) {
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported public key type: %T", key)
+	}
+	return publicKey, nil
+}
+
+// GetOwnerFromPublicKey - Convert the RSA Public Key to the base64url-encoded owner of its transactions
+func GetOwnerFromPublicKey(p *rsa.PublicKey) string {
+	return Base64URLEncode(p.N.Bytes())
+}
@@ -0,0 +1,49 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetPublicKeyFromPEM verifies SPKI and PKCS#1 public keys are parsed
+func TestGetPublicKeyFromPEM(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	spki, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	publicKey, err := GetPublicKeyFromPEM(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: spki}))
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(publicKey))
+
+	publicKey, err = GetPublicKeyFromPEM(pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&key.PublicKey)}))
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(publicKey))
+
+	publicKey, err = GetPublicKeyFromSPKI(spki)
+	require.NoError(t, err)
+	assert.Equal(t, GetOwnerFromPublicKey(&key.PublicKey), GetOwnerFromPublicKey(publicKey))
+}
+
+// TestGetPublicKeyFromPEMInvalid verifies non-RSA and malformed keys are rejected
+func TestGetPublicKeyFromPEMInvalid(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	spki, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	require.NoError(t, err)
+
+	_, err = GetPublicKeyFromPEM(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: spki}))
+	assert.Error(t, err)
+	_, err = GetPublicKeyFromPEM(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: spki}))
+	assert.Error(t, err)
+	_, err = GetPublicKeyFromPEM([]byte("not a key"))
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package crypto
 
 import (
 	"crypto/rsa"
+	"fmt"
 	"math/big"
 )
 
@@ -24,7 +25,7 @@ func GetPublicKeyFromOwner(owner string) (*rsa.PublicKey, error) {
 
 	return &rsa.PublicKey{
 		N: new(big.Int).SetBytes(data),
-		E: 65537, //"AQAB"
+		E: ARWEAVE_PUBLIC_EXPONENT, //"AQAB"
 	}, nil
 }
 
@@ -32,3 +33,18 @@ func GetPublicKeyFromOwner(owner string) (*rsa.PublicKey, error) {
 func GetAddressFromPublicKey(p *rsa.PublicKey) string {
 	return Base64URLEncode(SHA256(p.N.Bytes()))
 }
+
+// ValidateAddress - Check that the address is a base64url-encoded 32 byte SHA-256 hash
+func ValidateAddress(address string) error {
+	if len(address) != 43 {
+		return fmt.Errorf("invalid address %q: must be 43 characters", address)
+	}
+	data, err := Base64URLDecode(address)
+	if err != nil {
+		return fmt.Errorf("invalid address %q: %w", address, err)
+	}
+	if len(data) != 32 {
+		return fmt.Errorf("invalid address %q: must decode to 32 bytes", address)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAddress(t *testing.T) {
+	assert.NoError(t, ValidateAddress("F7fmxSBJx5RlIRrt825iIEAL110cKP2Bf8tYd0Q1STU"))
+	assert.NoError(t, ValidateAddress(Base64URLEncode(SHA256([]byte("owner")))))
+
+	for _, address := range []string{
+		"",
+		"F7fmxSBJx5RlIRrt825iIEAL110cKP2Bf8tYd0Q1ST",
+		"F7fmxSBJx5RlIRrt825iIEAL110cKP2Bf8tYd0Q1STUU",
+		"F7fmxSBJx5RlIRrt825iIEAL110cKP2Bf8tYd0Q1ST+",
+		"F7fmxSBJx5RlIRrt825iIEAL110cKP2Bf8tYd0Q1ST=",
+	} {
+		assert.Error(t, ValidateAddress(address), address)
+	}
+}
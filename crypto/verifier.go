@@ -0,0 +1,131 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/sha3"
+)
+
+// Signature types of ANS-104 data items.
+const (
+	SIGNATURE_TYPE_ARWEAVE  = 1 // RSA-PSS with SHA-256, 4096-bit key
+	SIGNATURE_TYPE_ED25519  = 2 // Ed25519
+	SIGNATURE_TYPE_ETHEREUM = 3 // secp256k1 ECDSA over an EIP-191 personal message
+	SIGNATURE_TYPE_SOLANA   = 4 // Ed25519 with a Solana key
+)
+
+// ARWEAVE_PUBLIC_EXPONENT is the RSA public exponent of every Arweave owner.
+// Owners only carry the modulus, so the protocol fixes the exponent.
+const ARWEAVE_PUBLIC_EXPONENT = 65537
+
+// Verifier checks signatures made by a single public key.
+type Verifier interface {
+	// Verify returns nil if signature is a valid signature of message.
+	Verify(message []byte, signature []byte) error
+}
+
+// NewVerifier creates a verifier for an owner of the given signature type.
+//
+// Arweave owners are verified with ARWEAVE_PUBLIC_EXPONENT: the owner field
+// only holds the modulus, and the Arweave node and arweave-js both verify
+// with e=65537, so a signature made with another exponent is invalid on the
+// network. Use NewRSAVerifier to verify a key with another exponent.
+//
+// Parameters:
+//   - signatureType: One of the SIGNATURE_TYPE constants
+//   - owner: The raw owner (public key) bytes
+//
+// Returns the Verifier, or an error if the signature type is unsupported or
+// the owner is not a valid public key for it.
+//
+// Example:
+//
+//	v, err := NewVerifier(SIGNATURE_TYPE_ED25519, rawOwner)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	err = v.Verify(message, signature)
+func NewVerifier(signatureType int, owner []byte) (Verifier, error) {
+	switch signatureType {
+	case SIGNATURE_TYPE_ARWEAVE:
+		if len(owner) == 0 {
+			return nil, errors.New("invalid owner - empty RSA modulus")
+		}
+		return NewRSAVerifier(&rsa.PublicKey{N: new(big.Int).SetBytes(owner), E: ARWEAVE_PUBLIC_EXPONENT}), nil
+	case SIGNATURE_TYPE_ED25519, SIGNATURE_TYPE_SOLANA:
+		if len(owner) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid owner - ed25519 public key must be %d bytes", ed25519.PublicKeySize)
+		}
+		return ed25519Verifier(append([]byte(nil), owner...)), nil
+	case SIGNATURE_TYPE_ETHEREUM:
+		publicKey, err := secp256k1.ParsePubKey(owner)
+		if err != nil {
+			return nil, fmt.Errorf("invalid owner - %w", err)
+		}
+		return &ethereumVerifier{publicKey: publicKey}, nil
+	}
+	return nil, fmt.Errorf("unsupported signature type: %d", signatureType)
+}
+
+// NewRSAVerifier creates a verifier for an RSA public key.
+//
+// Unlike NewVerifier, the exponent of the key is used as is, so keys loaded
+// from PEM or JWK with a non-standard exponent are verified correctly.
+func NewRSAVerifier(publicKey *rsa.PublicKey) Verifier {
+	return rsaVerifier{publicKey: publicKey}
+}
+
+// rsaVerifier verifies Arweave RSA-PSS signatures.
+type rsaVerifier struct {
+	publicKey *rsa.PublicKey
+}
+
+func (v rsaVerifier) Verify(message []byte, signature []byte) error {
+	return Verify(message, signature, v.publicKey)
+}
+
+// ed25519Verifier verifies Ed25519 signatures of the message itself.
+type ed25519Verifier ed25519.PublicKey
+
+func (v ed25519Verifier) Verify(message []byte, signature []byte) error {
+	if !ed25519.Verify(ed25519.PublicKey(v), message, signature) {
+		return errors.New("ed25519: verification error")
+	}
+	return nil
+}
+
+// ethereumVerifier verifies signatures made with Ethereum personal_sign
+// (EIP-191), as produced by Ethereum wallets signing a data item.
+type ethereumVerifier struct {
+	publicKey *secp256k1.PublicKey
+}
+
+func (v *ethereumVerifier) Verify(message []byte, signature []byte) error {
+	// r || s || v, where v is only needed for public key recovery
+	if len(signature) != 65 {
+		return errors.New("secp256k1: signature must be 65 bytes")
+	}
+	var r, s secp256k1.ModNScalar
+	if r.SetByteSlice(signature[:32]) || s.SetByteSlice(signature[32:64]) || r.IsZero() || s.IsZero() {
+		return errors.New("secp256k1: invalid signature")
+	}
+	if !ecdsa.NewSignature(&r, &s).Verify(ethereumMessageHash(message), v.publicKey) {
+		return errors.New("secp256k1: verification error")
+	}
+	return nil
+}
+
+// ethereumMessageHash returns the EIP-191 hash of a personal message.
+func ethereumMessageHash(message []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte("\x19Ethereum Signed Message:\n" + strconv.Itoa(len(message))))
+	h.Write(message)
+	return h.Sum(nil)
+}
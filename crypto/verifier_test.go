@@ -0,0 +1,89 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRSAVerifier verifies RSA owners and explicit exponents
+func TestRSAVerifier(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	message := []byte("message")
+	signature, err := Sign(message, key)
+	require.NoError(t, err)
+
+	v, err := NewVerifier(SIGNATURE_TYPE_ARWEAVE, key.N.Bytes())
+	require.NoError(t, err)
+	assert.NoError(t, v.Verify(message, signature))
+	assert.Error(t, v.Verify([]byte("other"), signature))
+
+	assert.NoError(t, NewRSAVerifier(&key.PublicKey).Verify(message, signature))
+	wrongExponent := &rsa.PublicKey{N: key.N, E: 3}
+	assert.Error(t, NewRSAVerifier(wrongExponent).Verify(message, signature))
+}
+
+// TestED25519Verifier verifies ED25519 and Solana owners
+func TestED25519Verifier(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	message := []byte("message")
+	signature := ed25519.Sign(privateKey, message)
+
+	for _, signatureType := range []int{SIGNATURE_TYPE_ED25519, SIGNATURE_TYPE_SOLANA} {
+		v, err := NewVerifier(signatureType, publicKey)
+		require.NoError(t, err)
+		assert.NoError(t, v.Verify(message, signature))
+		assert.Error(t, v.Verify([]byte("other"), signature))
+	}
+
+	_, err = NewVerifier(SIGNATURE_TYPE_ED25519, publicKey[:31])
+	assert.Error(t, err)
+}
+
+// TestEthereumVerifier verifies secp256k1 personal_sign signatures
+func TestEthereumVerifier(t *testing.T) {
+	key, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	message := []byte("message")
+
+	// Ethereum signatures are r || s || v
+	compact := ecdsa.SignCompact(key, ethereumMessageHash(message), false)
+	signature := append(append([]byte{}, compact[1:]...), compact[0]-27)
+
+	v, err := NewVerifier(SIGNATURE_TYPE_ETHEREUM, key.PubKey().SerializeUncompressed())
+	require.NoError(t, err)
+	assert.NoError(t, v.Verify(message, signature))
+	assert.Error(t, v.Verify([]byte("other"), signature))
+	assert.Error(t, v.Verify(message, signature[:64]))
+
+	// A signature of the raw message hash, without the EIP-191 prefix, is rejected
+	raw := ecdsa.SignCompact(key, SHA256(message), false)
+	assert.Error(t, v.Verify(message, append(append([]byte{}, raw[1:]...), raw[0]-27)))
+
+	_, err = NewVerifier(SIGNATURE_TYPE_ETHEREUM, make([]byte, 65))
+	assert.Error(t, err)
+}
+
+// TestEthereumMessageHash verifies the EIP-191 hash against ethers.utils.hashMessage
+func TestEthereumMessageHash(t *testing.T) {
+	assert.Equal(t, "50b2c43fd39106bafbba0da34fc430e1f91e3c96ea2acee2bc34119f92b37750", hex.EncodeToString(ethereumMessageHash([]byte("hello"))))
+}
+
+// TestNewVerifierUnsupported verifies unknown signature types are rejected
+func TestNewVerifierUnsupported(t *testing.T) {
+	_, err := NewVerifier(0, []byte{1})
+	assert.Error(t, err)
+	_, err = NewVerifier(5, []byte{1})
+	assert.Error(t, err)
+	_, err = NewVerifier(SIGNATURE_TYPE_ARWEAVE, nil)
+	assert.Error(t, err)
+}
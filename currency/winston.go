@@ -0,0 +1,248 @@
+// Package currency provides an exact representation of AR amounts.
+//
+// Arweave amounts are counted in Winston, the smallest unit of AR
+// (1 AR = 1,000,000,000,000 Winston). Balances and fees routinely exceed the
+// range of float64 integers, so Winston stores amounts as arbitrary precision
+// integers and never rounds.
+//
+// Example usage:
+//
+//	amount, err := currency.ParseAR("1.5")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	total := amount.Add(reward)
+//	if balance.Cmp(total) < 0 {
+//		log.Fatal("insufficient balance")
+//	}
+//	fmt.Printf("Sending %s AR (%s Winston)\n", amount.AR(), amount)
+package currency
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Unit constants
+const (
+	AR_DECIMALS    = 12                // Number of decimal places of AR
+	WINSTON_PER_AR = 1_000_000_000_000 // Number of Winston in one AR
+)
+
+var winstonPerAR = big.NewInt(WINSTON_PER_AR)
+
+// Winston is an amount of Winston.
+//
+// The zero value is 0 Winston. Winston values are immutable: arithmetic
+// methods return new values and never modify their operands, so values can be
+// copied and shared freely.
+//
+// Winston is encoded in JSON as a decimal string, as used by Arweave nodes.
+type Winston struct {
+	i *big.Int
+}
+
+// Zero is 0 Winston.
+var Zero = Winston{}
+
+// NewWinston creates a Winston amount from an int64.
+//
+// Example:
+//
+//	fee := NewWinston(1000)
+func NewWinston(v int64) Winston {
+	return Winston{i: big.NewInt(v)}
+}
+
+// FromBigInt creates a Winston amount from a big.Int.
+//
+// The value is copied, so later changes to v do not affect the result.
+func FromBigInt(v *big.Int) Winston {
+	if v == nil {
+		return Zero
+	}
+	return Winston{i: new(big.Int).Set(v)}
+}
+
+// ParseWinston parses a decimal integer amount of Winston.
+//
+// A leading '-' is accepted, so the negative results of Sub and NewWinston
+// read back from String and JSON. Check the sign of amounts that must be
+// positive, as Wallet.Transfer does.
+//
+// Parameters:
+//   - s: The amount, e.g. "1500000000000"
+//
+// Returns the parsed amount, or an error if s is not an integer.
+//
+// Example:
+//
+//	reward, err := ParseWinston("52342")
+func ParseWinston(s string) (Winston, error) {
+	if !isDigits(strings.TrimPrefix(s, "-")) {
+		return Zero, fmt.Errorf("invalid winston amount: %q", s)
+	}
+	i, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return Zero, fmt.Errorf("invalid winston amount: %q", s)
+	}
+	return Winston{i: i}, nil
+}
+
+// ParseAR parses a non-negative decimal amount of AR.
+//
+// Up to AR_DECIMALS fractional digits are accepted. Amounts with more
+// precision than one Winston are rejected instead of rounded.
+//
+// Parameters:
+//   - s: The amount, e.g. "1.5"
+//
+// Returns the amount in Winston, or an error if s is not a valid AR amount.
+//
+// Example:
+//
+//	amount, err := ParseAR("1.5") // 1500000000000 Winston
+func ParseAR(s string) (Winston, error) {
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" || whole != "" && !isDigits(whole) || frac != "" && !isDigits(frac) {
+		return Zero, fmt.Errorf("invalid AR amount: %q", s)
+	}
+	if len(frac) > AR_DECIMALS {
+		return Zero, fmt.Errorf("invalid AR amount: %q has more than %d decimal places", s, AR_DECIMALS)
+	}
+	digits := whole + frac + strings.Repeat("0", AR_DECIMALS-len(frac))
+	i, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Zero, fmt.Errorf("invalid AR amount: %q", s)
+	}
+	return Winston{i: i}, nil
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// int returns the underlying integer, treating the zero value as 0.
+func (w Winston) int() *big.Int {
+	if w.i == nil {
+		return new(big.Int)
+	}
+	return w.i
+}
+
+// BigInt returns a copy of the amount as a big.Int.
+func (w Winston) BigInt() *big.Int {
+	return new(big.Int).Set(w.int())
+}
+
+// String returns the amount in Winston as a decimal integer.
+func (w Winston) String() string {
+	return w.int().String()
+}
+
+// AR returns the amount in AR as a decimal string.
+//
+// Trailing zeros of the fractional part are removed.
+//
+// Example:
+//
+//	NewWinston(1500000000000).AR() // "1.5"
+func (w Winston) AR() string {
+	q, r := new(big.Int).QuoRem(w.int(), winstonPerAR, new(big.Int))
+	if r.Sign() == 0 {
+		return q.String()
+	}
+	sign := ""
+	if r.Sign() < 0 {
+		sign = "-"
+		q.Abs(q)
+		r.Abs(r)
+	}
+	frac := r.String()
+	frac = strings.Repeat("0", AR_DECIMALS-len(frac)) + frac
+	return sign + q.String() + "." + strings.TrimRight(frac, "0")
+}
+
+// Add returns w + o.
+func (w Winston) Add(o Winston) Winston {
+	return Winston{i: new(big.Int).Add(w.int(), o.int())}
+}
+
+// Sub returns w - o. The result can be negative.
+func (w Winston) Sub(o Winston) Winston {
+	return Winston{i: new(big.Int).Sub(w.int(), o.int())}
+}
+
+// Mul returns w * o.
+func (w Winston) Mul(o Winston) Winston {
+	return Winston{i: new(big.Int).Mul(w.int(), o.int())}
+}
+
+// Div returns w / o rounded towards zero.
+//
+// Div panics if o is zero.
+func (w Winston) Div(o Winston) Winston {
+	return Winston{i: new(big.Int).Quo(w.int(), o.int())}
+}
+
+// Cmp compares w and o.
+//
+// Returns -1 if w < o, 0 if w == o and +1 if w > o.
+func (w Winston) Cmp(o Winston) int {
+	return w.int().Cmp(o.int())
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of w.
+func (w Winston) Sign() int {
+	return w.int().Sign()
+}
+
+// IsZero reports whether w is 0.
+func (w Winston) IsZero() bool {
+	return w.Sign() == 0
+}
+
+// MarshalJSON encodes the amount as a JSON string of Winston.
+func (w Winston) MarshalJSON() ([]byte, error) {
+	return json.Marshal(w.String())
+}
+
+// UnmarshalJSON decodes an amount of Winston.
+//
+// Both JSON strings and numbers are accepted, including negative amounts
+// written by MarshalJSON. An empty string and null decode to 0.
+func (w *Winston) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if string(b) == "null" {
+		*w = Zero
+		return nil
+	}
+	s := string(b)
+	if len(b) > 0 && b[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		if s == "" {
+			*w = Zero
+			return nil
+		}
+	}
+	v, err := ParseWinston(s)
+	if err != nil {
+		return err
+	}
+	*w = v
+	return nil
+}
@@ -0,0 +1,143 @@
+package currency
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAR(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"0", "0"},
+		{"1", "1000000000000"},
+		{"1.5", "1500000000000"},
+		{".5", "500000000000"},
+		{"2.", "2000000000000"},
+		{"0.000000000001", "1"},
+		{"123456789.123456789012", "123456789123456789012"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			w, err := ParseAR(tc.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, w.String())
+		})
+	}
+
+	for _, input := range []string{"", ".", "-1", "+1", "1.2.3", "1e3", "abc", " 1", "0.0000000000001"} {
+		t.Run("Invalid "+input, func(t *testing.T) {
+			_, err := ParseAR(input)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseWinston(t *testing.T) {
+	w, err := ParseWinston("123456789012345678901234567890")
+	assert.NoError(t, err)
+	assert.Equal(t, "123456789012345678901234567890", w.String())
+
+	w, err = ParseWinston("-1500")
+	assert.NoError(t, err)
+	assert.Equal(t, -1, w.Sign())
+	assert.Equal(t, "-1500", w.String())
+
+	for _, input := range []string{"", "-", "--1", "+1", "1.5", "0x10", " 1"} {
+		_, err := ParseWinston(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestAR(t *testing.T) {
+	assert.Equal(t, "0", Zero.AR())
+	assert.Equal(t, "1", NewWinston(WINSTON_PER_AR).AR())
+	assert.Equal(t, "1.5", NewWinston(1500000000000).AR())
+	assert.Equal(t, "0.000000000001", NewWinston(1).AR())
+	assert.Equal(t, "-0.25", NewWinston(-250000000000).AR())
+	assert.Equal(t, "-2", NewWinston(-2*WINSTON_PER_AR).AR())
+}
+
+func TestArithmetic(t *testing.T) {
+	a := NewWinston(10)
+	b := NewWinston(3)
+
+	assert.Equal(t, "13", a.Add(b).String())
+	assert.Equal(t, "-7", b.Sub(a).String())
+	assert.Equal(t, "30", a.Mul(b).String())
+	assert.Equal(t, "3", a.Div(b).String())
+	assert.Equal(t, "10", a.String(), "operands must not be modified")
+
+	assert.Equal(t, 1, a.Cmp(b))
+	assert.Equal(t, -1, b.Cmp(a))
+	assert.Equal(t, 0, a.Cmp(NewWinston(10)))
+	assert.True(t, Zero.IsZero())
+	assert.True(t, Winston{}.Add(Zero).IsZero())
+	assert.Equal(t, -1, b.Sub(a).Sign())
+	assert.Panics(t, func() { a.Div(Zero) })
+}
+
+func TestFromBigInt(t *testing.T) {
+	i := big.NewInt(42)
+	w := FromBigInt(i)
+	i.SetInt64(0)
+	assert.Equal(t, "42", w.String())
+
+	b := w.BigInt()
+	b.SetInt64(1)
+	assert.Equal(t, "42", w.String())
+
+	assert.True(t, FromBigInt(nil).IsZero())
+}
+
+func TestJSON(t *testing.T) {
+	type payload struct {
+		Amount Winston `json:"amount"`
+	}
+
+	b, err := json.Marshal(payload{Amount: NewWinston(1500)})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"amount":"1500"}`, string(b))
+
+	b, err = json.Marshal(payload{})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"amount":"0"}`, string(b))
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{`{"amount":"1500"}`, "1500"},
+		{`{"amount":1500}`, "1500"},
+		{`{"amount":""}`, "0"},
+		{`{"amount":null}`, "0"},
+		{`{}`, "0"},
+		{`{"amount":"340282366920938463463374607431768211456"}`, "340282366920938463463374607431768211456"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			var p payload
+			assert.NoError(t, json.Unmarshal([]byte(tc.input), &p))
+			assert.Equal(t, tc.expected, p.Amount.String())
+		})
+	}
+
+	// Negative amounts, such as the result of Sub, read back
+	b, err = json.Marshal(payload{Amount: NewWinston(1).Sub(NewWinston(1500))})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"amount":"-1499"}`, string(b))
+	var back payload
+	assert.NoError(t, json.Unmarshal(b, &back))
+	assert.Equal(t, 0, back.Amount.Cmp(NewWinston(-1499)))
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount":-1}`), &back))
+	assert.Equal(t, "-1", back.Amount.String())
+
+	for _, input := range []string{`{"amount":"1.5"}`, `{"amount":"-"}`, `{"amount":1e3}`, `{"amount":true}`} {
+		var p payload
+		assert.Error(t, json.Unmarshal([]byte(input), &p), input)
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"log"
 
+	"github.com/liteseed/goar/currency"
 	"github.com/liteseed/goar/tag"
 	"github.com/liteseed/goar/transaction/data_item"
 	"github.com/liteseed/goar/wallet"
@@ -17,7 +18,7 @@ func SendBundle() {
 
 	var dataItems []data_item.DataItem
 	for i := 0; i < 10; i++ {
-		d := w.CreateDataItem([]byte("test"), "", "", &[]tag.Tag{{Name: "test", Value: "test"}})
+		d := w.CreateDataItem([]byte("test"), "", "", &[]tag.Tag{tag.ContentType("text/plain"), tag.AppName("goar-example")})
 		_, err = w.SignDataItem(d)
 		if err != nil {
 			log.Fatal(err)
@@ -30,7 +31,7 @@ func SendBundle() {
 		log.Fatal(err)
 	}
 
-	tx := w.CreateTransaction(b.Raw, "", "", &[]tag.Tag{{Name: "test", Value: "test"}, {Name: "test", Value: "test"}, {Name: "test", Value: "test"}})
+	tx := w.CreateTransaction(b.Raw, "", currency.Zero, &[]tag.Tag{tag.BundleFormat("binary"), tag.BundleVersion("2.0.0")})
 	_, err = w.SignTransaction(tx)
 	if err != nil {
 		log.Fatal(err)
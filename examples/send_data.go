@@ -3,6 +3,7 @@ package main
 import (
 	"log"
 
+	"github.com/liteseed/goar/currency"
 	"github.com/liteseed/goar/wallet"
 )
 
@@ -12,7 +13,7 @@ func SendData() {
 		log.Fatal(err)
 	}
 
-	tx := w.CreateTransaction([]byte("test"), "", "", nil)
+	tx := w.CreateTransaction([]byte("test"), "", currency.Zero, nil)
 	log.Println(tx)
 	_, err = w.SignTransaction(tx)
 	if err != nil {
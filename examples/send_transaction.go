@@ -3,6 +3,7 @@ package main
 import (
 	"log"
 
+	"github.com/liteseed/goar/currency"
 	"github.com/liteseed/goar/wallet"
 )
 
@@ -12,7 +13,7 @@ func SendTransaction() {
 		log.Fatal(err)
 	}
 
-	tx := w.CreateTransaction(nil, "F7fmxSBJx5RlIRrt825iIEAL110cKP2Bf8tYd0Q1STU", "100", nil)
+	tx := w.CreateTransaction(nil, "F7fmxSBJx5RlIRrt825iIEAL110cKP2Bf8tYd0Q1STU", currency.NewWinston(100), nil)
 	log.Println(tx)
 	_, err = w.SignTransaction(tx)
 	if err != nil {
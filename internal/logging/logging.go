@@ -0,0 +1,29 @@
+// Package logging holds the logging helpers shared by the goar packages.
+//
+// The library is silent by default: every package logs through an optional
+// *slog.Logger and discards records when none is set.
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+// discard is a logger whose handler drops every record before it is built.
+var discard = slog.New(discardHandler{})
+
+// OrDiscard returns l, or a logger that discards every record if l is nil.
+func OrDiscard(l *slog.Logger) *slog.Logger {
+	if l == nil {
+		return discard
+	}
+	return l
+}
+
+// discardHandler is a slog.Handler that is never enabled.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
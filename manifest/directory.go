@@ -0,0 +1,255 @@
+package manifest
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/liteseed/goar/currency"
+	"github.com/liteseed/goar/signer"
+	"github.com/liteseed/goar/tag"
+	"github.com/liteseed/goar/transaction"
+	"github.com/liteseed/goar/transaction/bundle"
+	"github.com/liteseed/goar/transaction/data_item"
+	"github.com/liteseed/goar/wallet"
+)
+
+// DEFAULT_INDEX is used as the index when Options.Index is empty and the
+// directory contains a file with this name.
+const DEFAULT_INDEX = "index.html"
+
+// Options configures how a directory is turned into a manifest.
+type Options struct {
+	Index    string     // Path of the index file relative to the directory (defaults to DEFAULT_INDEX when present)
+	Fallback string     // Path of the file served for unknown paths (optional)
+	Tags     *[]tag.Tag // Extra tags added to every file (optional)
+}
+
+// Result contains everything produced from a directory.
+//
+// BundleDirectory fills DataItems and Bundle, TransactionsFromDirectory fills
+// Transactions. In both cases the manifest is the last item.
+type Result struct {
+	ManifestID   string                     // ID of the manifest item or transaction
+	Manifest     *Manifest                  // The generated manifest
+	DataItems    []data_item.DataItem       // Signed file data items followed by the manifest data item
+	Bundle       *bundle.Bundle             // Bundle of all data items
+	Transactions []*transaction.Transaction // Signed file transactions followed by the manifest transaction
+}
+
+// file is a single file read from the directory.
+type file struct {
+	path string // Slash-separated path relative to the directory
+	data []byte
+}
+
+// BundleDirectory turns a directory tree into an ANS-104 bundle with a manifest.
+//
+// Every regular file becomes a signed data item tagged with its Content-Type.
+// A manifest data item mapping the relative file paths to the item IDs is
+// appended, and all items are packed into a single bundle.
+//
+// Parameters:
+//   - dir: The directory to bundle
+//   - s: The signer used to sign every data item
+//   - opts: Optional index, fallback and extra tags (can be nil)
+//
+// Returns the Result with the manifest ID, data items and bundle, or an error
+// if the directory cannot be read or signing fails.
+//
+// Example:
+//
+//	result, err := BundleDirectory("./site", s, nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	tx := w.CreateTransaction(result.Bundle.Raw, "", currency.Zero, &bundleTags)
+func BundleDirectory(dir string, s signer.Interface, opts *Options) (*Result, error) {
+	files, err := readDirectory(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]string, len(files))
+	var dataItems []data_item.DataItem
+	for _, f := range files {
+		d := data_item.New(f.data, "", "", fileTags(f, opts))
+		if err = d.Sign(s); err != nil {
+			return nil, err
+		}
+		ids[f.path] = d.ID
+		dataItems = append(dataItems, *d)
+	}
+
+	m, err := build(ids, opts)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := m.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	d := data_item.New(raw, "", "", manifestTags(opts))
+	if err = d.Sign(s); err != nil {
+		return nil, err
+	}
+	dataItems = append(dataItems, *d)
+
+	b, err := bundle.New(&dataItems)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{
+		ManifestID: d.ID,
+		Manifest:   m,
+		DataItems:  dataItems,
+		Bundle:     b,
+	}, nil
+}
+
+// TransactionsFromDirectory turns a directory tree into signed transactions with a manifest.
+//
+// Every regular file becomes a transaction tagged with its Content-Type and
+// signed by the wallet, which fetches the anchor and reward from the network.
+// A manifest transaction is appended. The transactions are not sent; use
+// Wallet.SendTransaction for each of them.
+//
+// Parameters:
+//   - w: The wallet used to sign the transactions
+//   - dir: The directory to upload
+//   - opts: Optional index, fallback and extra tags (can be nil)
+//
+// Returns the Result with the manifest ID and transactions, or an error if the
+// directory cannot be read or signing fails.
+//
+// Example:
+//
+//	result, err := TransactionsFromDirectory(w, "./site", nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, tx := range result.Transactions {
+//		if err := w.SendTransaction(tx); err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+func TransactionsFromDirectory(w *wallet.Wallet, dir string, opts *Options) (*Result, error) {
+	files, err := readDirectory(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]string, len(files))
+	var txs []*transaction.Transaction
+	for _, f := range files {
+		tx := w.CreateTransaction(f.data, "", currency.Zero, fileTags(f, opts))
+		if _, err = w.SignTransaction(tx); err != nil {
+			return nil, err
+		}
+		ids[f.path] = tx.ID
+		txs = append(txs, tx)
+	}
+
+	m, err := build(ids, opts)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := m.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	tx := w.CreateTransaction(raw, "", currency.Zero, manifestTags(opts))
+	if _, err = w.SignTransaction(tx); err != nil {
+		return nil, err
+	}
+	txs = append(txs, tx)
+
+	return &Result{
+		ManifestID:   tx.ID,
+		Manifest:     m,
+		Transactions: txs,
+	}, nil
+}
+
+// build creates the manifest for the given path to ID mapping.
+func build(ids map[string]string, opts *Options) (*Manifest, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	m := New()
+	for p, id := range ids {
+		m.AddPath(p, id)
+	}
+
+	index := filepath.ToSlash(opts.Index)
+	if index == "" {
+		if _, ok := ids[DEFAULT_INDEX]; ok {
+			index = DEFAULT_INDEX
+		}
+	}
+	if index != "" {
+		if err := m.SetIndex(index); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Fallback != "" {
+		id, ok := ids[filepath.ToSlash(opts.Fallback)]
+		if !ok {
+			return nil, fmt.Errorf("fallback path not found: %s", opts.Fallback)
+		}
+		m.SetFallback(id)
+	}
+	return m, nil
+}
+
+// readDirectory reads all regular files below dir in lexical order.
+func readDirectory(dir string) ([]file, error) {
+	var files []file
+	err := filepath.WalkDir(dir, func(p string, e fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !e.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files = append(files, file{path: filepath.ToSlash(rel), data: data})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files found in %s", dir)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].path < files[j].path })
+	return files, nil
+}
+
+// fileTags returns the detected Content-Type tag of a file followed by the extra tags.
+func fileTags(f file, opts *Options) *[]tag.Tag {
+	var extra *[]tag.Tag
+	if opts != nil {
+		extra = opts.Tags
+	}
+	return tag.WithContentType(extra, f.path, f.data)
+}
+
+// manifestTags returns the manifest Content-Type tag followed by the extra tags.
+func manifestTags(opts *Options) *[]tag.Tag {
+	tags := []tag.Tag{tag.ContentType(MANIFEST_CONTENT_TYPE)}
+	if opts != nil && opts.Tags != nil {
+		tags = append(tags, *opts.Tags...)
+	}
+	return &tags
+}
@@ -0,0 +1,101 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/liteseed/goar/signer"
+	"github.com/liteseed/goar/tag"
+	"github.com/liteseed/goar/transaction/bundle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createSite writes a small static site to a temporary directory
+func createSite(t *testing.T) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		"index.html":    "<html><body>index</body></html>",
+		"404.html":      "<html><body>not found</body></html>",
+		"css/style.css": "body { color: red; }",
+		"data":          "\x00\x01\x02binary",
+	}
+	for p, content := range files {
+		full := filepath.Join(dir, filepath.FromSlash(p))
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	return dir
+}
+
+// TestBundleDirectory verifies bundling a directory with a manifest
+func TestBundleDirectory(t *testing.T) {
+	s, err := signer.FromPath("../test/signer.json")
+	require.NoError(t, err)
+	dir := createSite(t)
+
+	extra := &[]tag.Tag{{Name: "App-Name", Value: "goar-test"}}
+	result, err := BundleDirectory(dir, s, &Options{Fallback: "404.html", Tags: extra})
+	require.NoError(t, err)
+
+	// 4 files and the manifest
+	require.Len(t, result.DataItems, 5)
+	manifestItem := result.DataItems[4]
+	assert.Equal(t, manifestItem.ID, result.ManifestID)
+	assert.Contains(t, *manifestItem.Tags, tag.Tag{Name: "Content-Type", Value: MANIFEST_CONTENT_TYPE})
+
+	m := result.Manifest
+	assert.Equal(t, "index.html", m.Index.Path)
+	assert.Len(t, m.Paths, 4)
+
+	contentTypes := map[string]string{}
+	for _, d := range result.DataItems[:4] {
+		require.NoError(t, d.Verify())
+		assert.Contains(t, *d.Tags, tag.Tag{Name: "App-Name", Value: "goar-test"})
+		for _, tg := range *d.Tags {
+			if tg.Name == "Content-Type" {
+				contentTypes[d.ID] = tg.Value
+			}
+		}
+	}
+	assert.Equal(t, "text/css; charset=utf-8", contentTypes[m.Paths["css/style.css"].ID])
+	assert.Equal(t, "text/html; charset=utf-8", contentTypes[m.Paths["index.html"].ID])
+	assert.Equal(t, "application/octet-stream", contentTypes[m.Paths["data"].ID])
+
+	fallback, err := m.Resolve("missing")
+	require.NoError(t, err)
+	assert.Equal(t, m.Paths["404.html"].ID, fallback)
+
+	// The bundle round trips and carries the manifest
+	b, err := bundle.Decode(result.Bundle.Raw)
+	require.NoError(t, err)
+	require.Len(t, b.Items, 5)
+	assert.Equal(t, result.ManifestID, b.Items[4].ID)
+}
+
+// TestBundleDirectoryErrors verifies invalid directories and options are rejected
+func TestBundleDirectoryErrors(t *testing.T) {
+	s, err := signer.FromPath("../test/signer.json")
+	require.NoError(t, err)
+
+	t.Run("Empty directory", func(t *testing.T) {
+		_, err := BundleDirectory(t.TempDir(), s, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("Missing directory", func(t *testing.T) {
+		_, err := BundleDirectory(filepath.Join(t.TempDir(), "missing"), s, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("Unknown fallback", func(t *testing.T) {
+		_, err := BundleDirectory(createSite(t), s, &Options{Fallback: "missing.html"})
+		assert.Error(t, err)
+	})
+
+	t.Run("Unknown index", func(t *testing.T) {
+		_, err := BundleDirectory(createSite(t), s, &Options{Index: "missing.html"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,196 @@
+// Package manifest provides functionality for building and resolving Arweave path manifests.
+//
+// A path manifest (arweave/paths) is a JSON document stored on Arweave with the
+// content type application/x.arweave-manifest+json. It maps relative paths to
+// transaction or data item IDs, which lets gateways serve a whole folder or static
+// site from a single manifest ID.
+//
+// Example usage:
+//
+//	// Bundle a static site
+//	result, err := manifest.BundleDirectory("./site", signer, &manifest.Options{Fallback: "404.html"})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Printf("Site manifest: %s\n", result.ManifestID)
+//
+//	// Resolve a path inside the manifest
+//	id, err := result.Manifest.Resolve("css/style.css")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+// Learn more: https://github.com/ArweaveTeam/arweave/wiki/Path-Manifests
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Manifest constants as defined by the arweave/paths specification.
+const (
+	MANIFEST_TYPE         = "arweave/paths"                       // Value of the "manifest" field
+	MANIFEST_VERSION      = "0.2.0"                               // Supported manifest version
+	MANIFEST_CONTENT_TYPE = "application/x.arweave-manifest+json" // Content-Type tag value of manifests
+)
+
+// Index points to the path served when the manifest root is requested.
+type Index struct {
+	Path string `json:"path"` // Path of the index file, must be a key of Paths
+}
+
+// Fallback points to the transaction served when no path matches.
+type Fallback struct {
+	ID string `json:"id"` // Transaction or data item ID of the fallback content
+}
+
+// Path points a manifest path to a transaction or data item.
+type Path struct {
+	ID string `json:"id"` // Transaction or data item ID of the content
+}
+
+// Manifest represents an arweave/paths manifest.
+type Manifest struct {
+	Manifest string          `json:"manifest"`           // Always MANIFEST_TYPE
+	Version  string          `json:"version"`            // Manifest version
+	Index    *Index          `json:"index,omitempty"`    // Optional index path
+	Fallback *Fallback       `json:"fallback,omitempty"` // Optional fallback ID
+	Paths    map[string]Path `json:"paths"`              // Path to ID mapping
+}
+
+// New creates an empty manifest.
+//
+// Returns a Manifest with the current type and version and no paths.
+//
+// Example:
+//
+//	m := New()
+//	m.AddPath("index.html", indexID)
+//	m.SetIndex("index.html")
+func New() *Manifest {
+	return &Manifest{
+		Manifest: MANIFEST_TYPE,
+		Version:  MANIFEST_VERSION,
+		Paths:    map[string]Path{},
+	}
+}
+
+// Parse decodes a manifest from its JSON representation.
+//
+// Parameters:
+//   - data: The manifest JSON
+//
+// Returns the decoded Manifest, or an error if the data is not a valid
+// arweave/paths manifest.
+//
+// Example:
+//
+//	data, err := client.GetTransactionData(manifestID)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	m, err := Parse(data)
+func Parse(data []byte) (*Manifest, error) {
+	m := &Manifest{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	if m.Manifest != MANIFEST_TYPE {
+		return nil, fmt.Errorf("invalid manifest type: %q", m.Manifest)
+	}
+	if m.Paths == nil {
+		m.Paths = map[string]Path{}
+	}
+	if m.Index != nil {
+		if _, ok := m.Paths[m.Index.Path]; !ok {
+			return nil, fmt.Errorf("invalid manifest - index path not found: %s", m.Index.Path)
+		}
+	}
+	return m, nil
+}
+
+// Marshal encodes the manifest to JSON.
+//
+// Returns the manifest JSON, or an error if the index does not point to a
+// known path.
+func (m *Manifest) Marshal() ([]byte, error) {
+	if m.Index != nil {
+		if _, ok := m.Paths[m.Index.Path]; !ok {
+			return nil, fmt.Errorf("invalid manifest - index path not found: %s", m.Index.Path)
+		}
+	}
+	return json.Marshal(m)
+}
+
+// AddPath maps a path to a transaction or data item ID.
+//
+// Leading slashes are removed and Windows separators are not translated,
+// so callers should pass slash-separated relative paths.
+//
+// Parameters:
+//   - path: The relative path, e.g. "css/style.css"
+//   - id: The ID serving the content for the path
+func (m *Manifest) AddPath(path string, id string) {
+	m.Paths[strings.TrimPrefix(path, "/")] = Path{ID: id}
+}
+
+// SetIndex sets the path served for the manifest root.
+//
+// Parameters:
+//   - path: A path previously added with AddPath
+//
+// Returns an error if the path is not part of the manifest.
+func (m *Manifest) SetIndex(path string) error {
+	path = strings.TrimPrefix(path, "/")
+	if _, ok := m.Paths[path]; !ok {
+		return fmt.Errorf("index path not found: %s", path)
+	}
+	m.Index = &Index{Path: path}
+	return nil
+}
+
+// SetFallback sets the ID served when no path matches.
+//
+// Parameters:
+//   - id: The transaction or data item ID of the fallback content
+func (m *Manifest) SetFallback(id string) {
+	m.Fallback = &Fallback{ID: id}
+}
+
+// Resolve maps a sub path of the manifest to a transaction or data item ID.
+//
+// The resolution follows the gateway behaviour:
+//   - An empty path resolves to the index
+//   - An exact path match resolves to its ID
+//   - Otherwise the fallback ID is returned if present
+//
+// Parameters:
+//   - subPath: The path relative to the manifest, e.g. "css/style.css"
+//
+// Returns the ID of the content, or an error if the path cannot be resolved.
+//
+// Example:
+//
+//	id, err := m.Resolve("css/style.css")
+//	if err != nil {
+//		log.Printf("Not found: %v", err)
+//	}
+func (m *Manifest) Resolve(subPath string) (string, error) {
+	subPath = strings.Trim(subPath, "/")
+	if subPath == "" {
+		if m.Index == nil {
+			return "", errors.New("manifest has no index")
+		}
+		subPath = m.Index.Path
+	}
+	if p, ok := m.Paths[subPath]; ok {
+		return p.ID, nil
+	}
+	if m.Fallback != nil && m.Fallback.ID != "" {
+		return m.Fallback.ID, nil
+	}
+	return "", fmt.Errorf("path not found in manifest: %s", subPath)
+}
@@ -0,0 +1,146 @@
+// Package manifest tests - verifies manifest encoding and path resolution
+package manifest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/liteseed/goar/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	indexID    = "cG7Hdi_iTQPoEYgQJFqJ8NMpN4KoZ-vH_j7pG4iP7NI"
+	styleID    = "3zFsd7bkCAUtXUKBQ4XiPiQvpLVKfZ6kiLNt2XVSfoV"
+	fallbackID = "iXo3LSfVKVtXUKBQ4XiPiQvpLVKfZ6kiLNt2XVSfoVk"
+)
+
+const manifestJSON = `{
+	"manifest": "arweave/paths",
+	"version": "0.2.0",
+	"index": {"path": "index.html"},
+	"fallback": {"id": "` + fallbackID + `"},
+	"paths": {
+		"index.html": {"id": "` + indexID + `"},
+		"css/style.css": {"id": "` + styleID + `"}
+	}
+}`
+
+// TestParse verifies decoding manifests from JSON
+func TestParse(t *testing.T) {
+	t.Run("Valid manifest", func(t *testing.T) {
+		m, err := Parse([]byte(manifestJSON))
+		require.NoError(t, err)
+		assert.Equal(t, MANIFEST_TYPE, m.Manifest)
+		assert.Equal(t, "index.html", m.Index.Path)
+		assert.Equal(t, fallbackID, m.Fallback.ID)
+		assert.Len(t, m.Paths, 2)
+	})
+
+	t.Run("Wrong manifest type", func(t *testing.T) {
+		_, err := Parse([]byte(`{"manifest":"other","paths":{}}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("Index not in paths", func(t *testing.T) {
+		_, err := Parse([]byte(`{"manifest":"arweave/paths","index":{"path":"a.html"},"paths":{}}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		_, err := Parse([]byte("{"))
+		assert.Error(t, err)
+	})
+}
+
+// TestMarshal verifies encoding manifests round trips through Parse
+func TestMarshal(t *testing.T) {
+	m := New()
+	m.AddPath("/index.html", indexID)
+	m.AddPath("css/style.css", styleID)
+	require.NoError(t, m.SetIndex("index.html"))
+	m.SetFallback(fallbackID)
+
+	data, err := m.Marshal()
+	require.NoError(t, err)
+
+	decoded, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, m, decoded)
+
+	assert.Error(t, m.SetIndex("missing.html"))
+}
+
+// TestResolve verifies resolving sub paths to IDs
+func TestResolve(t *testing.T) {
+	m, err := Parse([]byte(manifestJSON))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		path string
+		id   string
+	}{
+		{"Root", "", indexID},
+		{"Root slash", "/", indexID},
+		{"Exact path", "css/style.css", styleID},
+		{"Leading slash", "/css/style.css", styleID},
+		{"Unknown path", "missing.html", fallbackID},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			id, err := m.Resolve(tc.path)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.id, id)
+		})
+	}
+
+	t.Run("No fallback", func(t *testing.T) {
+		m.Fallback = nil
+		_, err := m.Resolve("missing.html")
+		assert.Error(t, err)
+	})
+
+	t.Run("No index", func(t *testing.T) {
+		m := New()
+		_, err := m.Resolve("")
+		assert.Error(t, err)
+	})
+}
+
+// TestResolver verifies resolving manifest paths through a gateway
+func TestResolver(t *testing.T) {
+	const manifestID = "manifest0000000000000000000000000000000000a"
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/"+manifestID {
+			http.NotFound(w, r)
+			return
+		}
+		requests.Add(1)
+		_, _ = w.Write([]byte(manifestJSON))
+	}))
+	defer server.Close()
+
+	r := NewResolver(client.New(server.URL))
+
+	id, err := r.Resolve(manifestID + "/css/style.css")
+	require.NoError(t, err)
+	assert.Equal(t, styleID, id)
+
+	id, err = r.Resolve(manifestID)
+	require.NoError(t, err)
+	assert.Equal(t, indexID, id)
+
+	// The manifest is cached after the first request
+	assert.Equal(t, int32(1), requests.Load())
+
+	_, err = r.Resolve("unknown/index.html")
+	assert.Error(t, err)
+
+	_, err = r.Resolve("")
+	assert.Error(t, err)
+}
@@ -0,0 +1,96 @@
+package manifest
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/liteseed/goar/client"
+)
+
+// Resolver resolves manifest paths against an Arweave gateway.
+//
+// Manifests are fetched once and cached, so resolving many paths of the same
+// manifest only downloads it a single time. A Resolver is safe for concurrent use.
+type Resolver struct {
+	client    *client.Client       // HTTP client used to fetch manifests
+	mu        sync.Mutex           // Guards manifests
+	manifests map[string]*Manifest // Cache of fetched manifests by ID
+}
+
+// NewResolver creates a Resolver that fetches manifests with the given client.
+//
+// Parameters:
+//   - c: The client used to download manifest data
+//
+// Returns a new Resolver with an empty cache.
+//
+// Example:
+//
+//	r := NewResolver(client.New("https://arweave.net"))
+//	id, err := r.Resolve("manifestID/css/style.css")
+func NewResolver(c *client.Client) *Resolver {
+	return &Resolver{
+		client:    c,
+		manifests: map[string]*Manifest{},
+	}
+}
+
+// Resolve maps a "manifestID/sub/path" string to a transaction or data item ID.
+//
+// The manifest is downloaded on first use and cached. The sub path is resolved
+// with Manifest.Resolve, so an empty sub path resolves to the index.
+//
+// Parameters:
+//   - p: The manifest ID followed by an optional sub path
+//
+// Returns the ID serving the path, or an error if the manifest cannot be
+// fetched or the path cannot be resolved.
+//
+// Example:
+//
+//	id, err := r.Resolve("Yx7m...manifestID/docs/index.html")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	data, err := c.GetTransactionData(id)
+func (r *Resolver) Resolve(p string) (string, error) {
+	manifestID, subPath, _ := strings.Cut(strings.TrimPrefix(p, "/"), "/")
+	if manifestID == "" {
+		return "", errors.New("manifest id is empty")
+	}
+	m, err := r.Manifest(manifestID)
+	if err != nil {
+		return "", err
+	}
+	return m.Resolve(subPath)
+}
+
+// Manifest returns the manifest with the given ID, fetching it if needed.
+//
+// Parameters:
+//   - id: The ID of the manifest transaction or data item
+//
+// Returns the parsed Manifest, or an error if it cannot be fetched or parsed.
+func (r *Resolver) Manifest(id string) (*Manifest, error) {
+	r.mu.Lock()
+	m, ok := r.manifests[id]
+	r.mu.Unlock()
+	if ok {
+		return m, nil
+	}
+
+	data, err := r.client.GetTransactionData(id)
+	if err != nil {
+		return nil, err
+	}
+	m, err = Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.manifests[id] = m
+	r.mu.Unlock()
+	return m, nil
+}
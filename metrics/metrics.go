@@ -0,0 +1,183 @@
+// Package metrics provides instrumentation hooks for goar.
+//
+// The client, uploader, wallet and bundle packages report counters and
+// histograms to a Recorder. The package has no dependencies: adapt a
+// Recorder to Prometheus, OpenTelemetry or any other backend, and trace HTTP
+// calls with client.Client.Use.
+//
+// Nothing is recorded by default. Memory is an in-memory Recorder for tests.
+//
+// Example:
+//
+//	m := metrics.NewMemory()
+//	c := client.New("https://arweave.net")
+//	c.Metrics = m
+//	c.GetNetworkInfo()
+//	fmt.Println(m.Keys())
+package metrics
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/liteseed/goar/signer"
+)
+
+// Metric names. Durations are in seconds and sizes in bytes.
+const (
+	HTTP_REQUESTS          = "goar_http_requests_total"            // Counter of gateway requests (labels: gateway, route, method, status)
+	HTTP_REQUEST_DURATION  = "goar_http_request_duration_seconds"  // Histogram of gateway request latency (labels: gateway, route, method, status)
+	UPLOADED_BYTES         = "goar_uploaded_bytes_total"           // Counter of request body bytes sent to gateways (labels: gateway, route)
+	CHUNK_RETRIES          = "goar_chunk_upload_retries_total"     // Counter of chunk uploads retried after an error
+	SIGN_DURATION          = "goar_sign_duration_seconds"          // Histogram of signing time (labels: kind)
+	BUNDLE_DECODE_DURATION = "goar_bundle_decode_duration_seconds" // Histogram of bundle decode time (labels: format, result)
+)
+
+// Label is a metric dimension.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Recorder receives measurements. Implementations must be safe for
+// concurrent use.
+type Recorder interface {
+	// Add adds delta to a counter.
+	Add(name string, delta float64, labels ...Label)
+	// Observe records a value in a histogram.
+	Observe(name string, value float64, labels ...Label)
+}
+
+// Nop is a Recorder that discards every measurement.
+var Nop Recorder = nop{}
+
+type nop struct{}
+
+func (nop) Add(string, float64, ...Label)     {}
+func (nop) Observe(string, float64, ...Label) {}
+
+// OrNop returns r, or Nop if r is nil.
+func OrNop(r Recorder) Recorder {
+	if r == nil {
+		return Nop
+	}
+	return r
+}
+
+// Since records the time elapsed since start, in seconds, in a histogram.
+//
+// Example:
+//
+//	defer metrics.Since(r, metrics.SIGN_DURATION, time.Now(), metrics.Label{Name: "kind", Value: "transaction"})
+func Since(r Recorder, name string, start time.Time, labels ...Label) {
+	r.Observe(name, time.Since(start).Seconds(), labels...)
+}
+
+// Memory is a Recorder that keeps every measurement in memory, for tests.
+//
+// Measurements are looked up by name and labels; the order of the labels
+// does not matter.
+type Memory struct {
+	mu           sync.Mutex
+	counters     map[string]float64
+	observations map[string][]float64
+}
+
+// NewMemory creates an empty in-memory recorder.
+func NewMemory() *Memory {
+	return &Memory{
+		counters:     map[string]float64{},
+		observations: map[string][]float64{},
+	}
+}
+
+// Add adds delta to a counter.
+func (m *Memory) Add(name string, delta float64, labels ...Label) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counters[key(name, labels)] += delta
+}
+
+// Observe records a value in a histogram.
+func (m *Memory) Observe(name string, value float64, labels ...Label) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	k := key(name, labels)
+	m.observations[k] = append(m.observations[k], value)
+}
+
+// Count returns the value of a counter, or 0 if it was never added to.
+func (m *Memory) Count(name string, labels ...Label) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.counters[key(name, labels)]
+}
+
+// Observations returns a copy of the values recorded in a histogram.
+func (m *Memory) Observations(name string, labels ...Label) []float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]float64(nil), m.observations[key(name, labels)]...)
+}
+
+// Keys returns the name and labels of every measurement recorded, sorted,
+// in the form name{label="value",...}.
+func (m *Memory) Keys() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	keys := make([]string, 0, len(m.counters)+len(m.observations))
+	for k := range m.counters {
+		keys = append(keys, k)
+	}
+	for k := range m.observations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// key formats a name and its labels, sorted by label name.
+func key(name string, labels []Label) string {
+	if len(labels) == 0 {
+		return name
+	}
+	sorted := append([]Label(nil), labels...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	var b strings.Builder
+	b.WriteString(name)
+	b.WriteByte('{')
+	for i, l := range sorted {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(l.Name + "=\"" + l.Value + "\"")
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// Signer times the signatures of a signer.Interface.
+//
+// Wrap a local or remote signer to record SIGN_DURATION for every signature,
+// whatever signs with it (transactions, data items or receipts).
+type Signer struct {
+	signer.Interface
+	Recorder Recorder
+}
+
+// NewSigner wraps s so every call to Sign is timed.
+//
+// Example:
+//
+//	d.Sign(metrics.NewSigner(w.Signer, recorder))
+func NewSigner(s signer.Interface, r Recorder) *Signer {
+	return &Signer{Interface: s, Recorder: r}
+}
+
+// Sign signs data with the wrapped signer and records the time it took.
+func (s *Signer) Sign(data []byte) ([]byte, error) {
+	defer Since(OrNop(s.Recorder), SIGN_DURATION, time.Now(), Label{Name: "kind", Value: "signer"})
+	return s.Interface.Sign(data)
+}
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/signer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMemory verifies measurements are looked up by name and labels in any order
+func TestMemory(t *testing.T) {
+	m := NewMemory()
+	a := Label{Name: "route", Value: "tx"}
+	b := Label{Name: "status", Value: "200"}
+
+	m.Add("requests", 1, a, b)
+	m.Add("requests", 2, b, a)
+	m.Add("requests", 5, a)
+	m.Observe("latency", 0.5, a)
+	m.Observe("latency", 1.5, a)
+
+	assert.Equal(t, 3.0, m.Count("requests", a, b))
+	assert.Equal(t, 5.0, m.Count("requests", a))
+	assert.Equal(t, 0.0, m.Count("requests"))
+	assert.Equal(t, []float64{0.5, 1.5}, m.Observations("latency", a))
+	assert.Empty(t, m.Observations("latency"))
+	assert.Equal(t, []string{
+		`latency{route="tx"}`,
+		`requests{route="tx",status="200"}`,
+		`requests{route="tx"}`,
+	}, m.Keys())
+}
+
+// TestMemoryConcurrent verifies the recorder can be shared between goroutines
+func TestMemoryConcurrent(t *testing.T) {
+	m := NewMemory()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m.Add("count", 1)
+				m.Observe("values", 1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 800.0, m.Count("count"))
+	assert.Len(t, m.Observations("values"), 800)
+}
+
+// TestNop verifies nil recorders are replaced by Nop
+func TestNop(t *testing.T) {
+	assert.Equal(t, Nop, OrNop(nil))
+	m := NewMemory()
+	assert.Equal(t, Recorder(m), OrNop(m))
+	Since(Nop, "duration", time.Now())
+}
+
+// TestSigner verifies signatures made through the wrapper are timed and unchanged
+func TestSigner(t *testing.T) {
+	s, err := signer.FromPath("../test/signer.json")
+	require.NoError(t, err)
+	m := NewMemory()
+
+	var _ signer.Interface = NewSigner(s, m)
+	wrapped := NewSigner(s, m)
+	assert.Equal(t, s.Owner(), wrapped.Owner())
+
+	message := make([]byte, 48)
+	signature, err := wrapped.Sign(message)
+	require.NoError(t, err)
+	assert.NoError(t, crypto.Verify(message, signature, s.PublicKey))
+	assert.Len(t, m.Observations(SIGN_DURATION, Label{Name: "kind", Value: "signer"}), 1)
+}
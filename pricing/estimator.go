@@ -0,0 +1,130 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/liteseed/goar/client"
+	"github.com/liteseed/goar/currency"
+)
+
+// DEFAULT_MAX_AGE is the age after which an Estimator refreshes its snapshot.
+const DEFAULT_MAX_AGE = time.Hour
+
+// BASIS_POINTS is the denominator of Estimator.Margin (10000 = 100%).
+const BASIS_POINTS = 10000
+
+// Estimator estimates transaction fees from a price snapshot.
+//
+// Estimates are computed offline from the snapshot. Only when the snapshot is
+// older than MaxAge, and a client is configured, is a new snapshot fetched
+// from the network.
+//
+// An Estimator is safe for concurrent use.
+type Estimator struct {
+	Client *client.Client // Client used to refresh a stale snapshot (nil for offline use)
+	MaxAge time.Duration  // Age after which the snapshot is stale (0 means never)
+	Margin int64          // Safety margin added to estimates in basis points, e.g. 500 for 5%
+	Sizes  []int          // Data sizes priced when refreshing (DEFAULT_SIZES if empty)
+
+	mu       sync.Mutex
+	snapshot *Snapshot
+}
+
+// NewEstimator creates an estimator.
+//
+// Parameters:
+//   - c: Client used to refresh the snapshot (can be nil for offline use)
+//   - s: Initial snapshot (can be nil, in which case the first estimate fetches one)
+//
+// Returns an Estimator with DEFAULT_MAX_AGE and no safety margin. When c is
+// nil the snapshot never expires.
+//
+// Example:
+//
+//	snapshot, err := pricing.LoadSnapshot("prices.json")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	e := pricing.NewEstimator(client, snapshot)
+//	e.Margin = 1000 // 10%
+func NewEstimator(c *client.Client, s *Snapshot) *Estimator {
+	e := &Estimator{Client: c, snapshot: s}
+	if c != nil {
+		e.MaxAge = DEFAULT_MAX_AGE
+	}
+	return e
+}
+
+// Snapshot returns the snapshot currently used for estimates.
+func (e *Estimator) Snapshot() *Snapshot {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.snapshot
+}
+
+// Estimate returns the estimated fee of a transaction.
+//
+// Whether a target wallet already exists cannot be known offline, so the new
+// wallet surcharge is included whenever target is set. Overpaying is accepted
+// by the network, underpaying is not.
+//
+// Parameters:
+//   - ctx: Context used if the snapshot has to be refreshed
+//   - size: Data size in bytes
+//   - target: Target address of the transaction (empty for data-only)
+//
+// Returns the fee including the safety margin, or an error if the snapshot is
+// stale and cannot be refreshed.
+//
+// Example:
+//
+//	fee, err := e.Estimate(ctx, len(data), "")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Printf("Upload will cost about %s AR\n", fee.AR())
+func (e *Estimator) Estimate(ctx context.Context, size int, target string) (currency.Winston, error) {
+	s, err := e.current(ctx)
+	if err != nil {
+		return currency.Zero, err
+	}
+	price, err := s.Price(size, target != "")
+	if err != nil {
+		return currency.Zero, err
+	}
+	return addMargin(price, e.Margin), nil
+}
+
+// current returns a snapshot that is not stale, refreshing it if needed.
+func (e *Estimator) current(ctx context.Context) (*Snapshot, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.snapshot != nil && (e.MaxAge == 0 || e.snapshot.Age() <= e.MaxAge) {
+		return e.snapshot, nil
+	}
+	if e.Client == nil {
+		if e.snapshot == nil {
+			return nil, errors.New("no price snapshot")
+		}
+		return nil, errors.New("price snapshot is stale")
+	}
+	s, err := Fetch(ctx, e.Client, e.Sizes)
+	if err != nil {
+		return nil, err
+	}
+	e.snapshot = s
+	return s, nil
+}
+
+// addMargin increases price by margin basis points, rounding up.
+func addMargin(price currency.Winston, margin int64) currency.Winston {
+	if margin <= 0 {
+		return price
+	}
+	scaled := price.Mul(currency.NewWinston(BASIS_POINTS + margin))
+	return divCeil(scaled, currency.NewWinston(BASIS_POINTS))
+}
@@ -0,0 +1,67 @@
+package pricing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/liteseed/goar/currency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimate(t *testing.T) {
+	s := &Snapshot{
+		Points:       []Point{{Size: 0, Price: currency.NewWinston(1000)}},
+		NewWalletFee: currency.NewWinston(7000),
+		Timestamp:    time.Now(),
+	}
+
+	t.Run("Offline", func(t *testing.T) {
+		e := NewEstimator(nil, s)
+		fee, err := e.Estimate(context.Background(), 0, "")
+		require.NoError(t, err)
+		assert.Equal(t, "1000", fee.String())
+
+		fee, err = e.Estimate(context.Background(), 0, "F7fmxSBJx5RlIRrt825iIEAL110cKP2Bf8tYd0Q1STU")
+		require.NoError(t, err)
+		assert.Equal(t, "8000", fee.String())
+	})
+
+	t.Run("Margin", func(t *testing.T) {
+		e := NewEstimator(nil, s)
+		e.Margin = 1234 // 12.34%
+		fee, err := e.Estimate(context.Background(), 0, "")
+		require.NoError(t, err)
+		assert.Equal(t, "1124", fee.String(), "1123.4 rounded up")
+	})
+
+	t.Run("Stale without client", func(t *testing.T) {
+		e := NewEstimator(nil, s)
+		e.MaxAge = time.Nanosecond
+		time.Sleep(time.Millisecond)
+		_, err := e.Estimate(context.Background(), 0, "")
+		assert.Error(t, err)
+
+		_, err = NewEstimator(nil, nil).Estimate(context.Background(), 0, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("Refreshes stale snapshot", func(t *testing.T) {
+		var requests int32
+		c := newPriceServer(t, &requests)
+
+		old := &Snapshot{Points: s.Points, Timestamp: time.Now().Add(-2 * DEFAULT_MAX_AGE)}
+		e := NewEstimator(c, old)
+		fee, err := e.Estimate(context.Background(), 1, "")
+		require.NoError(t, err)
+		assert.Equal(t, "1500", fee.String())
+		assert.NotSame(t, old, e.Snapshot())
+
+		// The fresh snapshot is used without further requests
+		before := requests
+		_, err = e.Estimate(context.Background(), 1, "")
+		require.NoError(t, err)
+		assert.Equal(t, before, requests)
+	})
+}
@@ -0,0 +1,235 @@
+// Package pricing provides offline estimation of Arweave transaction fees.
+//
+// Arweave charges per 256 KiB chunk of data, plus a fixed surcharge when a
+// transfer creates a new wallet. A Snapshot records the prices returned by
+// the /price endpoint at several data sizes; the fee of any other size is
+// interpolated from it without contacting the network. An Estimator adds a
+// safety margin and refreshes the snapshot from the network once it is stale.
+//
+// Example usage:
+//
+//	// Take a snapshot once and cache it
+//	snapshot, err := pricing.Fetch(ctx, client, nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	err = snapshot.Save("prices.json")
+//
+//	// Estimate offline
+//	e := pricing.NewEstimator(nil, snapshot)
+//	e.Margin = 500 // 5%
+//	fee, err := e.Estimate(ctx, len(data), "")
+//	fmt.Printf("Estimated fee: %s AR\n", fee.AR())
+package pricing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/liteseed/goar/client"
+	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/currency"
+	"github.com/liteseed/goar/transaction"
+)
+
+// DEFAULT_SIZES are the data sizes priced by Fetch when no sizes are given:
+// no data, and 1, 10, 100 and 1000 chunks.
+var DEFAULT_SIZES = []int{
+	0,
+	transaction.MAX_CHUNK_SIZE,
+	10 * transaction.MAX_CHUNK_SIZE,
+	100 * transaction.MAX_CHUNK_SIZE,
+	1000 * transaction.MAX_CHUNK_SIZE,
+}
+
+// Point is the network price of a data size.
+type Point struct {
+	Size  int              `json:"size"`  // Data size in bytes
+	Price currency.Winston `json:"price"` // Fee for the size without a new wallet target
+}
+
+// Snapshot is a set of network prices taken at one point in time.
+type Snapshot struct {
+	Points       []Point          `json:"points"`         // Prices ordered by size
+	NewWalletFee currency.Winston `json:"new_wallet_fee"` // Surcharge for transfers to wallets that do not exist yet
+	Timestamp    time.Time        `json:"timestamp"`      // When the prices were fetched
+}
+
+// Fetch takes a price snapshot from the network.
+//
+// Each size is priced with /price/{size}. The new wallet surcharge is the
+// difference between the price of an empty transaction to a random, unused
+// address and one without a target.
+//
+// Parameters:
+//   - ctx: Context for the requests
+//   - c: The client used to query prices
+//   - sizes: Data sizes to price (DEFAULT_SIZES if empty)
+//
+// Returns the Snapshot, or an error if any price cannot be fetched.
+func Fetch(ctx context.Context, c *client.Client, sizes []int) (*Snapshot, error) {
+	if len(sizes) == 0 {
+		sizes = DEFAULT_SIZES
+	}
+	s := &Snapshot{Timestamp: time.Now()}
+	var base *currency.Winston
+	for _, size := range sizes {
+		price, err := c.GetTransactionPriceContext(ctx, size, "")
+		if err != nil {
+			return nil, err
+		}
+		s.Points = append(s.Points, Point{Size: size, Price: price})
+		if size == 0 {
+			base = &price
+		}
+	}
+	s.sort()
+
+	// The empty transaction price is only fetched if it is not one of the sizes
+	if base == nil {
+		price, err := c.GetTransactionPriceContext(ctx, 0, "")
+		if err != nil {
+			return nil, err
+		}
+		base = &price
+	}
+	target, err := unusedAddress()
+	if err != nil {
+		return nil, err
+	}
+	withWallet, err := c.GetTransactionPriceContext(ctx, 0, target)
+	if err != nil {
+		return nil, err
+	}
+	if fee := withWallet.Sub(*base); fee.Sign() > 0 {
+		s.NewWalletFee = fee
+	}
+	return s, nil
+}
+
+// unusedAddress returns a random address that almost certainly has no wallet.
+func unusedAddress() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return crypto.Base64URLEncode(b), nil
+}
+
+// LoadSnapshot reads a snapshot saved with Save.
+//
+// Parameters:
+//   - path: The snapshot file
+//
+// Returns the Snapshot, or an error if the file cannot be read or is invalid.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSnapshot(b)
+}
+
+// ParseSnapshot decodes a snapshot from JSON.
+//
+// Returns the Snapshot, or an error if the JSON is invalid or has no prices.
+func ParseSnapshot(b []byte) (*Snapshot, error) {
+	s := &Snapshot{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, err
+	}
+	if len(s.Points) == 0 {
+		return nil, errors.New("invalid snapshot - no price points")
+	}
+	s.sort()
+	return s, nil
+}
+
+// Save writes the snapshot to a file as JSON.
+func (s *Snapshot) Save(path string) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
+
+// Age returns the time elapsed since the snapshot was taken.
+func (s *Snapshot) Age() time.Duration {
+	return time.Since(s.Timestamp)
+}
+
+// Price interpolates the network price of a data size from the snapshot.
+//
+// Sizes are converted to chunk counts, since Arweave charges per started
+// chunk. Between two points the price is linear in the chunk count; beyond
+// the last point the slope of the last two points is extended.
+//
+// Parameters:
+//   - size: Data size in bytes
+//   - newWallet: Whether to include the new wallet surcharge
+//
+// Returns the interpolated price, or an error if the snapshot has no points.
+func (s *Snapshot) Price(size int, newWallet bool) (currency.Winston, error) {
+	if len(s.Points) == 0 {
+		return currency.Zero, errors.New("snapshot has no price points")
+	}
+	if size < 0 {
+		return currency.Zero, fmt.Errorf("invalid data size: %d", size)
+	}
+
+	price := s.interpolate(chunks(size))
+	if newWallet {
+		price = price.Add(s.NewWalletFee)
+	}
+	return price, nil
+}
+
+// interpolate returns the price of n chunks.
+func (s *Snapshot) interpolate(n int64) currency.Winston {
+	points := s.Points
+	if len(points) == 1 || n <= chunks(points[0].Size) {
+		return points[0].Price
+	}
+
+	// Find the segment containing n, or the last segment to extrapolate
+	i := sort.Search(len(points), func(i int) bool { return chunks(points[i].Size) >= n })
+	if i == len(points) {
+		i = len(points) - 1
+	}
+	lo, hi := points[i-1], points[i]
+	loChunks, hiChunks := chunks(lo.Size), chunks(hi.Size)
+	if n == hiChunks || hiChunks == loChunks {
+		return hi.Price
+	}
+
+	// lo + (hi - lo) * (n - loChunks) / (hiChunks - loChunks), rounded up
+	span := currency.NewWinston(hiChunks - loChunks)
+	delta := hi.Price.Sub(lo.Price).Mul(currency.NewWinston(n - loChunks))
+	return lo.Price.Add(divCeil(delta, span))
+}
+
+// sort orders the points by size.
+func (s *Snapshot) sort() {
+	sort.SliceStable(s.Points, func(i, j int) bool { return s.Points[i].Size < s.Points[j].Size })
+}
+
+// chunks returns the number of chunks Arweave charges for a data size.
+func chunks(size int) int64 {
+	return (int64(size) + transaction.MAX_CHUNK_SIZE - 1) / transaction.MAX_CHUNK_SIZE
+}
+
+// divCeil returns a / b rounded towards positive infinity for positive b.
+func divCeil(a, b currency.Winston) currency.Winston {
+	q := a.Div(b)
+	if q.Mul(b).Cmp(a) < 0 {
+		q = q.Add(currency.NewWinston(1))
+	}
+	return q
+}
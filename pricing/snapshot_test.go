@@ -0,0 +1,143 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/liteseed/goar/client"
+	"github.com/liteseed/goar/currency"
+	"github.com/liteseed/goar/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBaseFee      = 1000
+	testChunkFee     = 500
+	testNewWalletFee = 7000
+)
+
+// newPriceServer serves /price with a linear per-chunk fee and counts requests.
+func newPriceServer(t *testing.T, requests *int32) *client.Client {
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		var size int64
+		rest := strings.TrimPrefix(r.URL.Path, "/price/")
+		parts := strings.Split(rest, "/")
+		if _, err := fmt.Sscan(parts[0], &size); err != nil {
+			http.NotFound(rw, r)
+			return
+		}
+		price := testBaseFee + testChunkFee*chunks(int(size))
+		if len(parts) > 1 && parts[1] != "" {
+			price += testNewWalletFee
+		}
+		fmt.Fprint(rw, price)
+	}))
+	t.Cleanup(s.Close)
+	return client.New(s.URL)
+}
+
+func TestFetch(t *testing.T) {
+	var requests int32
+	c := newPriceServer(t, &requests)
+
+	s, err := Fetch(context.Background(), c, nil)
+	require.NoError(t, err)
+	require.Len(t, s.Points, len(DEFAULT_SIZES))
+	assert.Equal(t, "1000", s.Points[0].Price.String())
+	assert.Equal(t, "1500", s.Points[1].Price.String())
+	assert.Equal(t, "7000", s.NewWalletFee.String())
+	assert.WithinDuration(t, time.Now(), s.Timestamp, time.Minute)
+
+	// One request per size plus one for the new wallet fee; the size-0 price is reused
+	assert.Equal(t, int32(len(DEFAULT_SIZES)+1), atomic.LoadInt32(&requests))
+
+	// Without size 0 among the sizes, the empty transaction is priced separately
+	atomic.StoreInt32(&requests, 0)
+	s, err = Fetch(context.Background(), c, []int{transaction.MAX_CHUNK_SIZE})
+	require.NoError(t, err)
+	assert.Equal(t, "7000", s.NewWalletFee.String())
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestPrice(t *testing.T) {
+	const chunk = transaction.MAX_CHUNK_SIZE
+	s := &Snapshot{
+		Points: []Point{
+			{Size: 0, Price: currency.NewWinston(1000)},
+			{Size: chunk, Price: currency.NewWinston(1500)},
+			{Size: 10 * chunk, Price: currency.NewWinston(6000)},
+		},
+		NewWalletFee: currency.NewWinston(7000),
+	}
+
+	testCases := []struct {
+		name      string
+		size      int
+		newWallet bool
+		expected  int64
+	}{
+		{"Empty", 0, false, 1000},
+		{"One byte is a whole chunk", 1, false, 1500},
+		{"Exact point", chunk, false, 1500},
+		{"Interpolated", 5*chunk + 1, false, 4000},
+		{"Last point", 10 * chunk, false, 6000},
+		{"Extrapolated", 20 * chunk, false, 11000},
+		{"New wallet", 0, true, 8000},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			price, err := s.Price(tc.size, tc.newWallet)
+			require.NoError(t, err)
+			assert.Equal(t, currency.NewWinston(tc.expected).String(), price.String())
+		})
+	}
+
+	t.Run("Rounds up", func(t *testing.T) {
+		s := &Snapshot{Points: []Point{
+			{Size: chunk, Price: currency.NewWinston(0)},
+			{Size: 4 * chunk, Price: currency.NewWinston(10)},
+		}}
+		price, err := s.Price(2*chunk, false)
+		require.NoError(t, err)
+		assert.Equal(t, "4", price.String())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := s.Price(-1, false)
+		assert.Error(t, err)
+		_, err = (&Snapshot{}).Price(0, false)
+		assert.Error(t, err)
+	})
+}
+
+func TestSaveLoadSnapshot(t *testing.T) {
+	s := &Snapshot{
+		Points: []Point{
+			{Size: 10, Price: currency.NewWinston(20)},
+			{Size: 0, Price: currency.NewWinston(10)},
+		},
+		NewWalletFee: currency.NewWinston(5),
+		Timestamp:    time.Unix(1700000000, 0).UTC(),
+	}
+	path := filepath.Join(t.TempDir(), "prices.json")
+	require.NoError(t, s.Save(path))
+
+	loaded, err := LoadSnapshot(path)
+	require.NoError(t, err)
+	assert.Equal(t, 0, loaded.Points[0].Size, "points are sorted by size")
+	assert.Equal(t, "20", loaded.Points[1].Price.String())
+	assert.Equal(t, "5", loaded.NewWalletFee.String())
+	assert.True(t, s.Timestamp.Equal(loaded.Timestamp))
+
+	_, err = ParseSnapshot([]byte(`{"points":[]}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,159 @@
+// Package receipt provides signed upload receipts issued by bundlers.
+//
+// When a bundler accepts a data item it promises to include it in a bundle
+// that is mined on Arweave before a deadline block height. The receipt is the
+// cryptographic proof of that promise: the bundler deep-hashes the receipt
+// fields and signs them with its wallet key, so anyone holding the receipt can
+// later prove what was promised.
+//
+// Example usage:
+//
+//	// Bundler side
+//	r := receipt.New(dataItem.ID, currentHeight+200)
+//	err := r.Sign(bundlerSigner)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	// Client side
+//	err = r.Verify()
+//	if err != nil {
+//		log.Printf("Invalid receipt: %v", err)
+//	}
+package receipt
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/signer"
+)
+
+// Receipt layout constants used when deep-hashing a receipt.
+const (
+	RECEIPT_PREFIX  = "Bundlr" // Domain separator of the standard receipt layout
+	RECEIPT_VERSION = "1.0.0"  // Receipt layout version
+)
+
+// Receipt represents a bundler's signed promise to settle a data item.
+//
+// The JSON representation matches the receipts returned by bundler nodes,
+// so receipts can be stored and verified by other implementations.
+type Receipt struct {
+	ID             string `json:"id"`             // ID of the data item the receipt is for
+	Timestamp      uint64 `json:"timestamp"`      // Unix time in milliseconds when the item was accepted
+	Version        string `json:"version"`        // Receipt layout version
+	Public         string `json:"public"`         // Base64url-encoded owner (public key) of the bundler
+	Signature      string `json:"signature"`      // Base64url-encoded signature over the receipt deep hash
+	DeadlineHeight uint64 `json:"deadlineHeight"` // Block height by which the item will be settled
+}
+
+// New creates an unsigned receipt for a data item.
+//
+// The receipt timestamp is set to the current time and the version to
+// RECEIPT_VERSION. Use Sign to attach the bundler's signature.
+//
+// Parameters:
+//   - id: The ID of the accepted data item
+//   - deadlineHeight: The block height by which the item will be settled
+//
+// Returns a new unsigned Receipt.
+//
+// Example:
+//
+//	r := New(dataItem.ID, 1450000)
+//	fmt.Printf("Receipt for %s valid until height %d\n", r.ID, r.DeadlineHeight)
+func New(id string, deadlineHeight uint64) *Receipt {
+	return &Receipt{
+		ID:             id,
+		Timestamp:      uint64(time.Now().UnixMilli()),
+		Version:        RECEIPT_VERSION,
+		DeadlineHeight: deadlineHeight,
+	}
+}
+
+// Sign signs the receipt with the bundler's key.
+//
+// This method sets the Public field to the signer's owner and the Signature
+// field to an RSA-PSS signature over the receipt deep hash.
+//
+// Parameters:
+//   - s: The bundler's signer
+//
+// Returns an error if the receipt is incomplete or signing fails.
+//
+// Example:
+//
+//	r := New(dataItem.ID, deadline)
+//	err := r.Sign(s)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func (r *Receipt) Sign(s signer.Interface) error {
+	r.Public = s.Owner()
+	signatureData, err := r.getSignatureData()
+	if err != nil {
+		return err
+	}
+	rawSignature, err := s.Sign(signatureData)
+	if err != nil {
+		return err
+	}
+	r.Signature = crypto.Base64URLEncode(rawSignature)
+	return nil
+}
+
+// Verify verifies the receipt signature against the bundler public key.
+//
+// Returns nil if the receipt was signed by the owner in the Public field,
+// or an error if the receipt is incomplete or the signature is invalid.
+//
+// Example:
+//
+//	err := r.Verify()
+//	if err != nil {
+//		log.Printf("Receipt is not valid: %v", err)
+//	}
+func (r *Receipt) Verify() error {
+	if r.Signature == "" {
+		return errors.New("receipt not signed")
+	}
+	signatureData, err := r.getSignatureData()
+	if err != nil {
+		return err
+	}
+	rawSignature, err := crypto.Base64URLDecode(r.Signature)
+	if err != nil {
+		return err
+	}
+	publicKey, err := crypto.GetPublicKeyFromOwner(r.Public)
+	if err != nil {
+		return err
+	}
+	if err = crypto.Verify(signatureData, rawSignature, publicKey); err != nil {
+		return fmt.Errorf("invalid receipt signature: %w", err)
+	}
+	return nil
+}
+
+// getSignatureData deep-hashes the receipt fields in the standard layout:
+// [prefix, version, id, deadline height, timestamp], all as UTF-8 strings.
+func (r *Receipt) getSignatureData() ([]byte, error) {
+	if r.ID == "" {
+		return nil, errors.New("receipt id is empty")
+	}
+	if r.Public == "" {
+		return nil, errors.New("receipt public key is empty")
+	}
+	chunks := [][]byte{
+		[]byte(RECEIPT_PREFIX),
+		[]byte(r.Version),
+		[]byte(r.ID),
+		[]byte(fmt.Sprint(r.DeadlineHeight)),
+		[]byte(fmt.Sprint(r.Timestamp)),
+	}
+	deepHash := crypto.DeepHash(chunks)
+	return deepHash[:], nil
+}
@@ -0,0 +1,75 @@
+// Package receipt tests - verifies receipt signing and verification
+package receipt
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/liteseed/goar/signer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const dataItemID = "QpmY8mZmFEC8RxNsgbxSV6e36OF6quIYaPRKzvUco0o"
+
+// TestNew verifies receipt creation
+func TestNew(t *testing.T) {
+	r := New(dataItemID, 1000)
+	assert.Equal(t, dataItemID, r.ID)
+	assert.Equal(t, uint64(1000), r.DeadlineHeight)
+	assert.Equal(t, RECEIPT_VERSION, r.Version)
+	assert.NotZero(t, r.Timestamp)
+	assert.Empty(t, r.Signature)
+}
+
+// TestSignVerify verifies signed receipts are accepted and tampered ones rejected
+func TestSignVerify(t *testing.T) {
+	s, err := signer.FromPath("../test/signer.json")
+	require.NoError(t, err)
+
+	t.Run("Valid receipt", func(t *testing.T) {
+		r := New(dataItemID, 1000)
+		require.NoError(t, r.Sign(s))
+		assert.Equal(t, s.Owner(), r.Public)
+		assert.NotEmpty(t, r.Signature)
+		assert.NoError(t, r.Verify())
+	})
+
+	t.Run("JSON round trip", func(t *testing.T) {
+		r := New(dataItemID, 1000)
+		require.NoError(t, r.Sign(s))
+
+		b, err := json.Marshal(r)
+		require.NoError(t, err)
+		assert.Contains(t, string(b), `"deadlineHeight":1000`)
+
+		decoded := &Receipt{}
+		require.NoError(t, json.Unmarshal(b, decoded))
+		assert.Equal(t, r, decoded)
+		assert.NoError(t, decoded.Verify())
+	})
+
+	t.Run("Tampered deadline", func(t *testing.T) {
+		r := New(dataItemID, 1000)
+		require.NoError(t, r.Sign(s))
+		r.DeadlineHeight = 2000
+		assert.Error(t, r.Verify())
+	})
+
+	t.Run("Tampered timestamp", func(t *testing.T) {
+		r := New(dataItemID, 1000)
+		require.NoError(t, r.Sign(s))
+		r.Timestamp++
+		assert.Error(t, r.Verify())
+	})
+
+	t.Run("Unsigned receipt", func(t *testing.T) {
+		r := New(dataItemID, 1000)
+		assert.Error(t, r.Verify())
+	})
+
+	t.Run("Empty ID", func(t *testing.T) {
+		r := New("", 1000)
+		assert.Error(t, r.Sign(s))
+	})
+}
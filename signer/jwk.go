@@ -0,0 +1,159 @@
+package signer
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/liteseed/goar/crypto"
+)
+
+// jwk is an RSA private key in JSON Web Key format (RFC 7518, section 6.3),
+// with the fields in the order written by Arweave wallets.
+type jwk struct {
+	Kty string `json:"kty"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	D   string `json:"d"`
+	P   string `json:"p,omitempty"`
+	Q   string `json:"q,omitempty"`
+	DP  string `json:"dp,omitempty"`
+	DQ  string `json:"dq,omitempty"`
+	QI  string `json:"qi,omitempty"`
+}
+
+// ToJWK exports the private key as a JWK.
+//
+// All RSA fields are written, including the prime factors (p, q) and CRT
+// parameters (dp, dq, qi), so the JWK loaded with FromJWK is exported
+// unchanged. The factors are omitted only if the key was loaded without them.
+//
+// Returns the JWK as JSON, or an error if the key is invalid.
+//
+// Example:
+//
+//	b, err := signer.ToJWK()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	err = os.WriteFile("wallet.json", b, 0o600)
+func (s *Signer) ToJWK() ([]byte, error) {
+	return encodeJWK(s.PrivateKey)
+}
+
+// encodeJWK serializes an RSA private key as a JWK.
+func encodeJWK(key *rsa.PrivateKey) ([]byte, error) {
+	if key == nil || key.N == nil || key.D == nil {
+		return nil, errors.New("invalid private key")
+	}
+	k := &jwk{
+		Kty: "RSA",
+		N:   crypto.Base64URLEncode(key.N.Bytes()),
+		E:   crypto.Base64URLEncode(big.NewInt(int64(key.E)).Bytes()),
+		D:   crypto.Base64URLEncode(key.D.Bytes()),
+	}
+	if len(key.Primes) == 2 {
+		if key.Precomputed.Dp == nil {
+			key.Precompute()
+		}
+		k.P = crypto.Base64URLEncode(key.Primes[0].Bytes())
+		k.Q = crypto.Base64URLEncode(key.Primes[1].Bytes())
+		k.DP = crypto.Base64URLEncode(key.Precomputed.Dp.Bytes())
+		k.DQ = crypto.Base64URLEncode(key.Precomputed.Dq.Bytes())
+		k.QI = crypto.Base64URLEncode(key.Precomputed.Qinv.Bytes())
+	} else if len(key.Primes) > 2 {
+		return nil, errors.New("multi-prime RSA keys are not supported")
+	}
+	return json.Marshal(k)
+}
+
+// decodeJWK parses an RSA private key from a JWK.
+//
+// When the prime factors are present the CRT parameters must be consistent
+// with them and the key is validated. Keys without factors are accepted as
+// written by older tools.
+func decodeJWK(b []byte) (*rsa.PrivateKey, error) {
+	k := &jwk{}
+	if err := json.Unmarshal(b, k); err != nil {
+		return nil, err
+	}
+	if k.Kty != "RSA" {
+		return nil, fmt.Errorf("unsupported key type: %q", k.Kty)
+	}
+	if k.N == "" || k.E == "" || k.D == "" {
+		return nil, errors.New("invalid JWK - missing n, e or d")
+	}
+
+	n, err := decodeJWKInt("n", k.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := decodeJWKInt("e", k.E)
+	if err != nil {
+		return nil, err
+	}
+	if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid JWK - unsupported public exponent")
+	}
+	d, err := decodeJWKInt("d", k.D)
+	if err != nil {
+		return nil, err
+	}
+	key := &rsa.PrivateKey{
+		PublicKey: rsa.PublicKey{N: n, E: int(e.Int64())},
+		D:         d,
+	}
+	if k.P == "" && k.Q == "" {
+		return key, nil
+	}
+
+	p, err := decodeJWKInt("p", k.P)
+	if err != nil {
+		return nil, err
+	}
+	q, err := decodeJWKInt("q", k.Q)
+	if err != nil {
+		return nil, err
+	}
+	key.Primes = []*big.Int{p, q}
+	key.Precompute()
+	if err = key.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid JWK - %w", err)
+	}
+
+	crt := []struct {
+		name     string
+		value    string
+		expected *big.Int
+	}{
+		{"dp", k.DP, key.Precomputed.Dp},
+		{"dq", k.DQ, key.Precomputed.Dq},
+		{"qi", k.QI, key.Precomputed.Qinv},
+	}
+	for _, c := range crt {
+		if c.value == "" {
+			continue
+		}
+		v, err := decodeJWKInt(c.name, c.value)
+		if err != nil {
+			return nil, err
+		}
+		if v.Cmp(c.expected) != 0 {
+			return nil, fmt.Errorf("invalid JWK - %s does not match the key", c.name)
+		}
+	}
+	return key, nil
+}
+
+// decodeJWKInt decodes a base64url-encoded big-endian integer field,
+// tolerating padding.
+func decodeJWKInt(name string, value string) (*big.Int, error) {
+	b, err := crypto.Base64URLDecode(strings.TrimRight(value, "="))
+	if err != nil || len(b) == 0 {
+		return nil, fmt.Errorf("invalid JWK - malformed %s", name)
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,94 @@
+package signer
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestToJWKRoundTrip verifies every RSA field of a wallet JWK is exported unchanged
+func TestToJWKRoundTrip(t *testing.T) {
+	data, err := os.ReadFile("../test/signer.json")
+	require.NoError(t, err)
+	var original map[string]any
+	require.NoError(t, json.Unmarshal(data, &original))
+
+	s, err := FromJWK(data)
+	require.NoError(t, err)
+	require.Len(t, s.PrivateKey.Primes, 2)
+
+	b, err := s.ToJWK()
+	require.NoError(t, err)
+	var exported map[string]any
+	require.NoError(t, json.Unmarshal(b, &exported))
+
+	for _, field := range []string{"kty", "n", "e", "d", "p", "q", "dp", "dq", "qi"} {
+		assert.Equal(t, original[field], exported[field], field)
+	}
+	assert.NotContains(t, exported, "ext")
+}
+
+// TestFromJWKWithoutPrimes verifies keys holding only n, e and d are still accepted
+func TestFromJWKWithoutPrimes(t *testing.T) {
+	s, err := FromPath("../test/signer.json")
+	require.NoError(t, err)
+	b, err := json.Marshal(map[string]string{
+		"kty": "RSA",
+		"n":   s.Owner(),
+		"e":   "AQAB",
+		"d":   mustField(t, s, "d"),
+	})
+	require.NoError(t, err)
+
+	loaded, err := FromJWK(b)
+	require.NoError(t, err)
+	assert.Equal(t, s.Address, loaded.Address)
+	assert.Empty(t, loaded.PrivateKey.Primes)
+
+	exported, err := loaded.ToJWK()
+	require.NoError(t, err)
+	assert.NotContains(t, string(exported), `"p"`)
+}
+
+// TestFromJWKInvalidFields verifies malformed or inconsistent keys are rejected
+func TestFromJWKInvalidFields(t *testing.T) {
+	data, err := os.ReadFile("../test/signer.json")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		field string
+		value any
+	}{
+		{"key type", "kty", "EC"},
+		{"missing d", "d", ""},
+		{"malformed n", "n", "not base64!"},
+		{"wrong exponent", "e", "Aw"},
+		{"inconsistent dp", "dp", "AQAB"},
+		{"inconsistent qi", "qi", "AQAB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var k map[string]any
+			require.NoError(t, json.Unmarshal(data, &k))
+			k[tt.field] = tt.value
+			b, err := json.Marshal(k)
+			require.NoError(t, err)
+
+			_, err = FromJWK(b)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// mustField returns a field of the JWK export of s
+func mustField(t *testing.T, s *Signer, field string) string {
+	b, err := s.ToJWK()
+	require.NoError(t, err)
+	var k map[string]string
+	require.NoError(t, json.Unmarshal(b, &k))
+	return k[field]
+}
@@ -0,0 +1,317 @@
+package signer
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/liteseed/goar/crypto"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Encrypted keystore format constants.
+const (
+	KEYSTORE_VERSION = 1             // Supported keystore format version
+	KEYSTORE_KDF     = "scrypt"      // Key derivation function
+	KEYSTORE_CIPHER  = "aes-256-gcm" // Cipher used to encrypt the JWK
+)
+
+// Limits on the scrypt parameters of a keystore. scrypt uses 128·N·r bytes
+// of memory and p times the work of one derivation, so decrypting a crafted
+// keystore uses at most 1 GiB of memory and the time of four derivations
+// with N=2^20 and r=8 (about 16 times the time of DefaultScryptParams).
+const (
+	maxScryptN = 1 << 20
+	maxScryptR = 8
+	maxScryptP = 4
+)
+
+const (
+	keystoreKeyLen  = 32 // AES-256 key size
+	keystoreSaltLen = 32
+)
+
+// ErrInvalidPassphrase is returned when a keystore cannot be decrypted,
+// either because the passphrase is wrong or because the keystore was altered.
+var ErrInvalidPassphrase = errors.New("invalid passphrase or corrupted keystore")
+
+// ScryptParams are the cost parameters of the scrypt key derivation.
+type ScryptParams struct {
+	N int `json:"n"` // CPU/memory cost, a power of two
+	R int `json:"r"` // Block size
+	P int `json:"p"` // Parallelism
+}
+
+// DefaultScryptParams are the parameters used by Export. Deriving a key takes
+// 256 MiB of memory and around a second on current hardware.
+var DefaultScryptParams = ScryptParams{N: 1 << 18, R: 8, P: 1}
+
+// keystore is the versioned JSON envelope of an encrypted JWK.
+//
+// The JWK is encrypted with AES-256-GCM under a key derived from the
+// passphrase with scrypt. The version and address are authenticated as
+// additional data, so they cannot be changed without the passphrase.
+type keystore struct {
+	Version    int       `json:"version"`    // Format version
+	Address    string    `json:"address"`    // Address of the wallet, readable without the passphrase
+	KDF        string    `json:"kdf"`        // Always KEYSTORE_KDF
+	KDFParams  kdfParams `json:"kdfparams"`  // Key derivation parameters
+	Cipher     string    `json:"cipher"`     // Always KEYSTORE_CIPHER
+	Nonce      string    `json:"nonce"`      // Base64url-encoded GCM nonce
+	Ciphertext string    `json:"ciphertext"` // Base64url-encoded encrypted JWK and tag
+}
+
+type kdfParams struct {
+	ScryptParams
+	Salt   string `json:"salt"`  // Base64url-encoded salt
+	KeyLen int    `json:"dklen"` // Derived key length
+}
+
+// Export encrypts the private key with a passphrase.
+//
+// The key is serialized as a JWK and encrypted with DefaultScryptParams.
+//
+// Parameters:
+//   - passphrase: The passphrase protecting the key
+//
+// Returns the encrypted keystore as JSON, or an error if encryption fails.
+//
+// Example:
+//
+//	b, err := signer.Export(passphrase)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	err = os.WriteFile("wallet.keystore.json", b, 0o600)
+func (s *Signer) Export(passphrase string) ([]byte, error) {
+	return s.ExportWithParams(passphrase, DefaultScryptParams)
+}
+
+// ExportWithParams encrypts the private key with a passphrase and custom
+// scrypt parameters.
+//
+// Parameters:
+//   - passphrase: The passphrase protecting the key
+//   - params: The scrypt cost parameters
+//
+// Returns the encrypted keystore as JSON, or an error if the parameters are
+// above the limits accepted for decryption (N=2^20, r=8, p=4) or encryption
+// fails.
+func (s *Signer) ExportWithParams(passphrase string, params ScryptParams) ([]byte, error) {
+	plaintext, err := encodeJWK(s.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(plaintext)
+	return encrypt(s.Address, plaintext, passphrase, params)
+}
+
+// FromEncryptedPath creates a Signer from an encrypted keystore file.
+//
+// Parameters:
+//   - path: The keystore file written from Export
+//   - passphrase: The passphrase the keystore was encrypted with
+//
+// Returns the Signer, ErrInvalidPassphrase if the passphrase is wrong, or an
+// error if the file cannot be read or is not a valid keystore.
+//
+// Example:
+//
+//	signer, err := FromEncryptedPath("wallet.keystore.json", os.Getenv("WALLET_PASSPHRASE"))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func FromEncryptedPath(path string, passphrase string) (*Signer, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return FromEncrypted(b, passphrase)
+}
+
+// FromEncrypted creates a Signer from an encrypted keystore in memory.
+//
+// Parameters:
+//   - b: The keystore JSON
+//   - passphrase: The passphrase the keystore was encrypted with
+//
+// Returns the Signer, ErrInvalidPassphrase if the passphrase is wrong, or an
+// error if the keystore is invalid.
+func FromEncrypted(b []byte, passphrase string) (*Signer, error) {
+	ks, err := parseKeystore(b)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := ks.decrypt(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(plaintext)
+
+	s, err := FromJWK(plaintext)
+	if err != nil {
+		return nil, err
+	}
+	if s.Address != ks.Address {
+		return nil, errors.New("invalid keystore - address does not match the key")
+	}
+	return s, nil
+}
+
+// ChangePassphrase re-encrypts a keystore under a new passphrase.
+//
+// The key is never written out in the clear. The new keystore keeps the
+// scrypt parameters of the old one but uses a fresh salt and nonce.
+//
+// Parameters:
+//   - b: The keystore JSON
+//   - oldPassphrase: The current passphrase
+//   - newPassphrase: The new passphrase
+//
+// Returns the re-encrypted keystore as JSON, ErrInvalidPassphrase if the
+// current passphrase is wrong, or an error if the keystore is invalid.
+//
+// Example:
+//
+//	b, err := os.ReadFile("wallet.keystore.json")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	b, err = signer.ChangePassphrase(b, oldPassphrase, newPassphrase)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	err = os.WriteFile("wallet.keystore.json", b, 0o600)
+func ChangePassphrase(b []byte, oldPassphrase string, newPassphrase string) ([]byte, error) {
+	ks, err := parseKeystore(b)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := ks.decrypt(oldPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(plaintext)
+	return encrypt(ks.Address, plaintext, newPassphrase, ks.KDFParams.ScryptParams)
+}
+
+// parseKeystore decodes a keystore and checks that its format is supported.
+func parseKeystore(b []byte) (*keystore, error) {
+	ks := &keystore{}
+	if err := json.Unmarshal(b, ks); err != nil {
+		return nil, err
+	}
+	if ks.Version != KEYSTORE_VERSION {
+		return nil, fmt.Errorf("unsupported keystore version: %d", ks.Version)
+	}
+	if ks.KDF != KEYSTORE_KDF {
+		return nil, fmt.Errorf("unsupported keystore kdf: %q", ks.KDF)
+	}
+	if ks.Cipher != KEYSTORE_CIPHER {
+		return nil, fmt.Errorf("unsupported keystore cipher: %q", ks.Cipher)
+	}
+	if err := ks.KDFParams.validate(); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// validate checks the parameters are within the limits accepted for decryption.
+func (p *kdfParams) validate() error {
+	if p.KeyLen != keystoreKeyLen {
+		return fmt.Errorf("invalid keystore - unsupported key length: %d", p.KeyLen)
+	}
+	if p.N < 2 || p.N&(p.N-1) != 0 || p.N > maxScryptN {
+		return fmt.Errorf("invalid keystore - scrypt n out of range: %d", p.N)
+	}
+	if p.R < 1 || p.R > maxScryptR || p.P < 1 || p.P > maxScryptP {
+		return fmt.Errorf("invalid keystore - scrypt r or p out of range: r=%d p=%d", p.R, p.P)
+	}
+	return nil
+}
+
+// decrypt derives the key from the passphrase and decrypts the JWK.
+func (ks *keystore) decrypt(passphrase string) ([]byte, error) {
+	salt, err := crypto.Base64URLDecode(ks.KDFParams.Salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := crypto.Base64URLDecode(ks.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := crypto.Base64URLDecode(ks.Ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(passphrase, salt, ks.KDFParams.ScryptParams)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid keystore - bad nonce size")
+	}
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData(ks.Version, ks.Address))
+	if err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+	return plaintext, nil
+}
+
+// encrypt builds a keystore for plaintext with a fresh salt and nonce.
+func encrypt(address string, plaintext []byte, passphrase string, params ScryptParams) ([]byte, error) {
+	if err := (&kdfParams{ScryptParams: params, KeyLen: keystoreKeyLen}).validate(); err != nil {
+		return nil, err
+	}
+	salt := make([]byte, keystoreSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(passphrase, salt, params)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	ciphertext := aead.Seal(nil, nonce, plaintext, additionalData(KEYSTORE_VERSION, address))
+
+	return json.Marshal(&keystore{
+		Version: KEYSTORE_VERSION,
+		Address: address,
+		KDF:     KEYSTORE_KDF,
+		KDFParams: kdfParams{
+			ScryptParams: params,
+			Salt:         crypto.Base64URLEncode(salt),
+			KeyLen:       keystoreKeyLen,
+		},
+		Cipher:     KEYSTORE_CIPHER,
+		Nonce:      crypto.Base64URLEncode(nonce),
+		Ciphertext: crypto.Base64URLEncode(ciphertext),
+	})
+}
+
+// newAEAD derives the encryption key and returns the AES-GCM cipher.
+func newAEAD(passphrase string, salt []byte, params ScryptParams) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, keystoreKeyLen)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(key)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// additionalData binds the version and address to the ciphertext.
+func additionalData(version int, address string) []byte {
+	return []byte(fmt.Sprintf("%d:%s", version, address))
+}
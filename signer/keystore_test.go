@@ -0,0 +1,157 @@
+package signer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testScryptParams keeps key derivation fast in tests
+var testScryptParams = ScryptParams{N: 1 << 10, R: 8, P: 1}
+
+// TestExportRoundTrip verifies an exported keystore decrypts to the same key
+func TestExportRoundTrip(t *testing.T) {
+	s, err := FromPath("../test/signer.json")
+	require.NoError(t, err)
+
+	b, err := s.ExportWithParams("correct horse", testScryptParams)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "wallet.keystore.json")
+	require.NoError(t, os.WriteFile(path, b, 0o600))
+
+	loaded, err := FromEncryptedPath(path, "correct horse")
+	require.NoError(t, err)
+	assert.Equal(t, s.Address, loaded.Address)
+	assert.True(t, s.PrivateKey.Equal(loaded.PrivateKey))
+}
+
+// TestExportDoesNotLeakKey verifies the keystore contains no private key material
+func TestExportDoesNotLeakKey(t *testing.T) {
+	s, err := FromPath("../test/signer.json")
+	require.NoError(t, err)
+
+	b, err := s.ExportWithParams("correct horse", testScryptParams)
+	require.NoError(t, err)
+
+	var ks map[string]any
+	require.NoError(t, json.Unmarshal(b, &ks))
+	assert.Equal(t, float64(KEYSTORE_VERSION), ks["version"])
+	assert.Equal(t, s.Address, ks["address"])
+	assert.Equal(t, KEYSTORE_KDF, ks["kdf"])
+	assert.Equal(t, KEYSTORE_CIPHER, ks["cipher"])
+	assert.NotContains(t, string(b), `"d"`)
+	assert.NotContains(t, string(b), s.Owner())
+}
+
+// TestFromEncryptedWrongPassphrase verifies a wrong passphrase is rejected
+func TestFromEncryptedWrongPassphrase(t *testing.T) {
+	s, err := FromPath("../test/signer.json")
+	require.NoError(t, err)
+	b, err := s.ExportWithParams("correct horse", testScryptParams)
+	require.NoError(t, err)
+
+	_, err = FromEncrypted(b, "battery staple")
+	assert.ErrorIs(t, err, ErrInvalidPassphrase)
+}
+
+// TestFromEncryptedTampered verifies altered keystores are rejected
+func TestFromEncryptedTampered(t *testing.T) {
+	s, err := FromPath("../test/signer.json")
+	require.NoError(t, err)
+	b, err := s.ExportWithParams("correct horse", testScryptParams)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		modify func(ks *keystore)
+	}{
+		{"address", func(ks *keystore) { ks.Address = "x" + ks.Address[1:] }},
+		{"salt", func(ks *keystore) { ks.KDFParams.Salt = "AAAA" + ks.KDFParams.Salt[4:] }},
+		{"ciphertext", func(ks *keystore) { ks.Ciphertext = "AAAA" + ks.Ciphertext[4:] }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks, err := parseKeystore(b)
+			require.NoError(t, err)
+			tt.modify(ks)
+			tampered, err := json.Marshal(ks)
+			require.NoError(t, err)
+
+			_, err = FromEncrypted(tampered, "correct horse")
+			assert.ErrorIs(t, err, ErrInvalidPassphrase)
+		})
+	}
+}
+
+// TestFromEncryptedInvalid verifies unsupported or unsafe keystores are rejected before decryption
+func TestFromEncryptedInvalid(t *testing.T) {
+	s, err := FromPath("../test/signer.json")
+	require.NoError(t, err)
+	b, err := s.ExportWithParams("correct horse", testScryptParams)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		modify func(ks *keystore)
+	}{
+		{"version", func(ks *keystore) { ks.Version = 2 }},
+		{"kdf", func(ks *keystore) { ks.KDF = "pbkdf2" }},
+		{"cipher", func(ks *keystore) { ks.Cipher = "aes-128-ctr" }},
+		{"n not power of two", func(ks *keystore) { ks.KDFParams.N = 1000 }},
+		{"n too large", func(ks *keystore) { ks.KDFParams.N = 1 << 30 }},
+		{"n above limit", func(ks *keystore) { ks.KDFParams.N = 1 << 21 }},
+		{"r above limit", func(ks *keystore) { ks.KDFParams.R = 9 }},
+		{"p too large", func(ks *keystore) { ks.KDFParams.P = 5 }},
+		{"key length", func(ks *keystore) { ks.KDFParams.KeyLen = 16 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks, err := parseKeystore(b)
+			require.NoError(t, err)
+			tt.modify(ks)
+			invalid, err := json.Marshal(ks)
+			require.NoError(t, err)
+
+			_, err = FromEncrypted(invalid, "correct horse")
+			assert.Error(t, err)
+			assert.NotErrorIs(t, err, ErrInvalidPassphrase)
+		})
+	}
+
+	// Keystores that could not be decrypted are not written
+	_, err = s.ExportWithParams("correct horse", ScryptParams{N: 1 << 21, R: 8, P: 1})
+	assert.Error(t, err)
+
+	_, err = FromEncrypted([]byte("not json"), "correct horse")
+	assert.Error(t, err)
+}
+
+// TestChangePassphrase verifies re-encryption under a new passphrase
+func TestChangePassphrase(t *testing.T) {
+	s, err := FromPath("../test/signer.json")
+	require.NoError(t, err)
+	b, err := s.ExportWithParams("correct horse", testScryptParams)
+	require.NoError(t, err)
+
+	_, err = ChangePassphrase(b, "wrong", "battery staple")
+	assert.ErrorIs(t, err, ErrInvalidPassphrase)
+
+	changed, err := ChangePassphrase(b, "correct horse", "battery staple")
+	require.NoError(t, err)
+
+	_, err = FromEncrypted(changed, "correct horse")
+	assert.ErrorIs(t, err, ErrInvalidPassphrase)
+
+	loaded, err := FromEncrypted(changed, "battery staple")
+	require.NoError(t, err)
+	assert.Equal(t, s.Address, loaded.Address)
+
+	ks, err := parseKeystore(changed)
+	require.NoError(t, err)
+	assert.Equal(t, testScryptParams, ks.KDFParams.ScryptParams)
+}
@@ -0,0 +1,225 @@
+package signer
+
+import (
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+// MNEMONIC_ENTROPY_BITS is the entropy of mnemonics created by NewMnemonic,
+// which gives 12 words.
+const MNEMONIC_ENTROPY_BITS = 128
+
+// mnemonicKeyBits is the size of keys derived from a mnemonic.
+const mnemonicKeyBits = 4096
+
+// NewMnemonic creates a random BIP-39 mnemonic.
+//
+// The mnemonic is the only backup needed for the key: FromMnemonic always
+// derives the same key from the same words.
+//
+// Returns 12 space-separated English words, or an error if the system random
+// source fails.
+//
+// Example:
+//
+//	words, err := NewMnemonic()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println("Write down your recovery phrase:", words)
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(MNEMONIC_ENTROPY_BITS)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// FromMnemonic derives a Signer from a BIP-39 mnemonic.
+//
+// The BIP-39 seed of the words (with an empty passphrase) is the entropy of an
+// HMAC-DRBG with SHA-256, which drives the generation of a 4096-bit RSA key
+// with exponent 65537. The prime search follows node-forge, as
+// arweave-mnemonic-keys uses it, but it is not checked against keys from that
+// library: the same words may restore a different address in JavaScript
+// wallets. Only restoring keys created with NewMnemonic is supported.
+//
+// Parameters:
+//   - words: The mnemonic (case and extra whitespace are ignored)
+//
+// Returns the derived Signer, or an error if the mnemonic is invalid.
+//
+// Example:
+//
+//	signer, err := FromMnemonic("abandon abandon ... about")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Printf("Recovered wallet: %s\n", signer.Address)
+func FromMnemonic(words string) (*Signer, error) {
+	mnemonic := strings.Join(strings.Fields(strings.ToLower(words)), " ")
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	if err != nil {
+		return nil, err
+	}
+	key, err := deterministicKey(newDRBG(seed), mnemonicKeyBits)
+	if err != nil {
+		return nil, err
+	}
+	return FromPrivateKey(key), nil
+}
+
+// gcd30Delta are the steps between the numbers coprime with 30, starting from
+// 30k+1.
+var gcd30Delta = [8]int64{6, 4, 2, 4, 2, 4, 6, 2}
+
+// deterministicKey generates an RSA key with exponent 65537 from a
+// deterministic random stream, the way node-forge does in
+// pki.rsa.generateKeyPair with a seeded PRNG.
+//
+// rsa.GenerateKey cannot be used as it deliberately consumes a random number
+// of bytes from its reader, so its output is not reproducible.
+func deterministicKey(r *drbg, bits int) (*rsa.PrivateKey, error) {
+	e := big.NewInt(65537)
+	one := big.NewInt(1)
+	coprime := func(n *big.Int) bool {
+		return new(big.Int).GCD(nil, nil, n, e).Cmp(one) == 0
+	}
+
+	pBits, qBits := bits-bits/2, bits/2
+	p := deterministicPrime(r, pBits)
+	q := deterministicPrime(r, qBits)
+	var pm1, qm1, phi, n *big.Int
+	for {
+		if p.Cmp(q) < 0 {
+			p, q = q, p
+		}
+		pm1 = new(big.Int).Sub(p, one)
+		qm1 = new(big.Int).Sub(q, one)
+		if !coprime(pm1) {
+			p = deterministicPrime(r, pBits)
+			continue
+		}
+		if !coprime(qm1) {
+			q = deterministicPrime(r, qBits)
+			continue
+		}
+		phi = new(big.Int).Mul(pm1, qm1)
+		if !coprime(phi) {
+			p = deterministicPrime(r, pBits)
+			q = deterministicPrime(r, qBits)
+			continue
+		}
+		n = new(big.Int).Mul(p, q)
+		if n.BitLen() != bits {
+			q = deterministicPrime(r, qBits)
+			continue
+		}
+		break
+	}
+
+	d := new(big.Int).ModInverse(e, phi)
+	if d == nil {
+		return nil, errors.New("failed to derive private exponent")
+	}
+	key := &rsa.PrivateKey{
+		PublicKey: rsa.PublicKey{N: n, E: int(e.Int64())},
+		D:         d,
+		Primes:    []*big.Int{p, q},
+	}
+	key.Precompute()
+	if err := key.Validate(); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// deterministicPrime returns a probable prime of bits bits, the way the
+// PRIMEINC algorithm of node-forge finds it: from a random number with its
+// top bit set and aligned on 30k+1, it steps through the numbers coprime with
+// 30 until one is prime, and starts again from a new random number if the
+// search passes bits bits.
+func deterministicPrime(r *drbg, bits int) *big.Int {
+	p := deterministicRandom(r, bits)
+	for i := 0; ; i++ {
+		if p.BitLen() > bits {
+			p = deterministicRandom(r, bits)
+		}
+		if p.ProbablyPrime(20) {
+			return p
+		}
+		p.Add(p, big.NewInt(gcd30Delta[i%8]))
+	}
+}
+
+// deterministicRandom reads a number of bits bits with its top bit set and
+// adds to it to reach the next number congruent to 1 modulo 30.
+//
+// Like the BigInteger constructor of node-forge, it reads bits/8+1 bytes and
+// keeps the low bits%8 bits of the first.
+func deterministicRandom(r *drbg, bits int) *big.Int {
+	b := make([]byte, bits/8+1)
+	r.Read(b)
+	b[0] &= byte(1<<(bits%8) - 1)
+
+	n := new(big.Int).SetBytes(b)
+	n.SetBit(n, bits-1, 1)
+	m := new(big.Int).Mod(n, big.NewInt(30)).Int64()
+	return n.Add(n, big.NewInt(31-m))
+}
+
+// drbg is an HMAC-DRBG (NIST SP 800-90A) with SHA-256, without reseeding.
+type drbg struct {
+	k []byte
+	v []byte
+}
+
+// newDRBG instantiates the generator with seed as its entropy input.
+func newDRBG(seed []byte) *drbg {
+	d := &drbg{
+		k: make([]byte, sha256.Size),
+		v: make([]byte, sha256.Size),
+	}
+	for i := range d.v {
+		d.v[i] = 0x01
+	}
+	d.update(seed)
+	return d
+}
+
+// update mixes data into the generator state.
+func (d *drbg) update(data []byte) {
+	d.k = d.hmac(d.v, []byte{0x00}, data)
+	d.v = d.hmac(d.v)
+	if len(data) == 0 {
+		return
+	}
+	d.k = d.hmac(d.v, []byte{0x01}, data)
+	d.v = d.hmac(d.v)
+}
+
+// Read fills b with output of the generator. It never fails.
+func (d *drbg) Read(b []byte) (int, error) {
+	n := 0
+	for n < len(b) {
+		d.v = d.hmac(d.v)
+		n += copy(b[n:], d.v)
+	}
+	d.update(nil)
+	return n, nil
+}
+
+// hmac returns HMAC-SHA256 of the concatenated parts under the current key.
+func (d *drbg) hmac(parts ...[]byte) []byte {
+	h := hmac.New(sha256.New, d.k)
+	for _, p := range parts {
+		h.Write(p)
+	}
+	return h.Sum(nil)
+}
@@ -0,0 +1,110 @@
+package signer
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/liteseed/goar/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// mnemonicVectors are mnemonics from the BIP-39 test vectors and the addresses
+// derived from them. They must never change, or existing wallets would be lost.
+// The keys of the JavaScript wallets are checked in test/vectors.
+var mnemonicVectors = []struct {
+	words   string
+	address string
+}{
+	{
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"l55sI4sCbT9d9AV6WKz2DQpnW4Ld0EcBAZv-CMv_HAQ",
+	},
+	{
+		"legal winner thank year wave sausage worth useful legal winner thank yellow",
+		"4gko33o-g-_iAJ-EaBQT_MX1m3GqW380z6Nz3HuszAk",
+	},
+}
+
+// TestFromMnemonicVectors verifies mnemonics always derive the same address
+func TestFromMnemonicVectors(t *testing.T) {
+	for _, v := range mnemonicVectors {
+		s, err := FromMnemonic(v.words)
+		require.NoError(t, err)
+		assert.Equal(t, v.address, s.Address)
+		assert.Equal(t, 4096, s.PublicKey.N.BitLen())
+		assert.Equal(t, 65537, s.PublicKey.E)
+		require.NoError(t, s.PrivateKey.Validate())
+
+		// The derived key signs like any other key
+		signature, err := crypto.Sign([]byte("hello"), s.PrivateKey)
+		require.NoError(t, err)
+		assert.NoError(t, crypto.Verify([]byte("hello"), signature, s.PublicKey))
+	}
+}
+
+// TestFromMnemonicNormalization verifies case and whitespace do not change the key
+func TestFromMnemonicNormalization(t *testing.T) {
+	v := mnemonicVectors[1]
+	s, err := FromMnemonic("  " + strings.ToUpper(strings.ReplaceAll(v.words, " ", "  \n")))
+	require.NoError(t, err)
+	assert.Equal(t, v.address, s.Address)
+}
+
+// TestFromMnemonicInvalid verifies invalid mnemonics are rejected
+func TestFromMnemonicInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"abandon abandon abandon",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", // Bad checksum
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon goar",    // Unknown word
+	}
+	for _, words := range tests {
+		_, err := FromMnemonic(words)
+		assert.Error(t, err, words)
+	}
+}
+
+// TestNewMnemonic verifies new mnemonics are valid and distinct
+func TestNewMnemonic(t *testing.T) {
+	a, err := NewMnemonic()
+	require.NoError(t, err)
+	b, err := NewMnemonic()
+	require.NoError(t, err)
+
+	assert.Len(t, strings.Fields(a), 12)
+	assert.True(t, bip39.IsMnemonicValid(a))
+	assert.NotEqual(t, a, b)
+}
+
+// TestDRBGDeterministic verifies the generator output depends only on its seed
+func TestDRBGDeterministic(t *testing.T) {
+	read := func(seed string) []byte {
+		b := make([]byte, 100)
+		_, err := newDRBG([]byte(seed)).Read(b)
+		require.NoError(t, err)
+		return b
+	}
+	assert.Equal(t, read("seed"), read("seed"))
+	assert.NotEqual(t, read("seed"), read("other seed"))
+}
+
+// TestDeterministicRandom verifies numbers are read like node-forge and aligned on 30k+1
+func TestDeterministicRandom(t *testing.T) {
+	for _, bits := range []int{16, 21, 2048} {
+		n := deterministicRandom(newDRBG([]byte("seed")), bits)
+		assert.Equal(t, bits, n.BitLen(), bits)
+		assert.Equal(t, int64(1), new(big.Int).Mod(n, big.NewInt(30)).Int64(), bits)
+	}
+
+	// 2048 bits are read from 257 bytes, the first of which is dropped
+	b := make([]byte, 257)
+	_, err := newDRBG([]byte("seed")).Read(b)
+	require.NoError(t, err)
+	expected := new(big.Int).SetBytes(b[1:])
+	expected.SetBit(expected, 2047, 1)
+	offset := new(big.Int).Sub(deterministicRandom(newDRBG([]byte("seed")), 2048), expected)
+	assert.True(t, offset.Sign() > 0 && offset.Cmp(big.NewInt(31)) <= 0, offset)
+}
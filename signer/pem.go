@@ -0,0 +1,88 @@
+package signer
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// PEM block types read by FromPEM.
+const (
+	PEM_PKCS8_TYPE = "PRIVATE KEY"     // PKCS#8 private key, written by ToPEM
+	PEM_PKCS1_TYPE = "RSA PRIVATE KEY" // PKCS#1 RSA private key
+)
+
+// FromPEM creates a Signer from a PEM-encoded RSA private key.
+//
+// Both PKCS#8 ("PRIVATE KEY") and PKCS#1 ("RSA PRIVATE KEY") blocks are
+// accepted. Encrypted PEM blocks are not supported; use an encrypted keystore
+// instead.
+//
+// Parameters:
+//   - b: The PEM data (only the first block is read)
+//
+// Returns the Signer, or an error if the data holds no RSA private key.
+//
+// Example:
+//
+//	b, err := os.ReadFile("wallet.pem")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	signer, err := FromPEM(b)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func FromPEM(b []byte) (*Signer, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("invalid PEM - no block found")
+	}
+
+	var privateKey *rsa.PrivateKey
+	switch block.Type {
+	case PEM_PKCS8_TYPE:
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		var ok bool
+		if privateKey, ok = key.(*rsa.PrivateKey); !ok {
+			return nil, fmt.Errorf("unsupported private key type: %T", key)
+		}
+	case PEM_PKCS1_TYPE:
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		privateKey = key
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type: %q", block.Type)
+	}
+	return FromPrivateKey(privateKey), nil
+}
+
+// ToPEM exports the private key as a PKCS#8 PEM block.
+//
+// Returns the PEM data, or an error if the key cannot be encoded, for example
+// because it was loaded from a JWK without its prime factors.
+//
+// Example:
+//
+//	b, err := signer.ToPEM()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	err = os.WriteFile("wallet.pem", b, 0o600)
+func (s *Signer) ToPEM() ([]byte, error) {
+	if len(s.PrivateKey.Primes) < 2 {
+		return nil, errors.New("private key has no prime factors")
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(s.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: PEM_PKCS8_TYPE, Bytes: der}), nil
+}
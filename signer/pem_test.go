@@ -0,0 +1,77 @@
+package signer
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/liteseed/goar/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPEMRoundTrip verifies a key exported with ToPEM loads back unchanged
+func TestPEMRoundTrip(t *testing.T) {
+	s, err := FromPath("../test/signer.json")
+	require.NoError(t, err)
+
+	b, err := s.ToPEM()
+	require.NoError(t, err)
+	block, _ := pem.Decode(b)
+	require.NotNil(t, block)
+	assert.Equal(t, PEM_PKCS8_TYPE, block.Type)
+
+	loaded, err := FromPEM(b)
+	require.NoError(t, err)
+	assert.Equal(t, s.Address, loaded.Address)
+	assert.True(t, s.PrivateKey.Equal(loaded.PrivateKey))
+
+	// PEM to JWK gives back the original wallet fields
+	original, err := s.ToJWK()
+	require.NoError(t, err)
+	converted, err := loaded.ToJWK()
+	require.NoError(t, err)
+	assert.JSONEq(t, string(original), string(converted))
+}
+
+// TestFromPEMPKCS1 verifies PKCS#1 RSA private keys are accepted
+func TestFromPEMPKCS1(t *testing.T) {
+	s, err := FromPath("../test/signer.json")
+	require.NoError(t, err)
+	b := pem.EncodeToMemory(&pem.Block{Type: PEM_PKCS1_TYPE, Bytes: x509.MarshalPKCS1PrivateKey(s.PrivateKey)})
+
+	loaded, err := FromPEM(b)
+	require.NoError(t, err)
+	assert.Equal(t, s.Address, loaded.Address)
+}
+
+// TestFromPEMInvalid verifies unsupported PEM data is rejected
+func TestFromPEMInvalid(t *testing.T) {
+	s, err := FromPath("../test/signer.json")
+	require.NoError(t, err)
+	spki, err := x509.MarshalPKIXPublicKey(s.PublicKey)
+	require.NoError(t, err)
+
+	tests := map[string][]byte{
+		"not PEM":     []byte("not a key"),
+		"public key":  pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: spki}),
+		"corrupt DER": pem.EncodeToMemory(&pem.Block{Type: PEM_PKCS8_TYPE, Bytes: []byte{0x30, 0x01}}),
+	}
+	for name, b := range tests {
+		_, err := FromPEM(b)
+		assert.Error(t, err, name)
+	}
+}
+
+// TestPublicKeyFromPEM verifies verifiers can derive the owner and address from a public key alone
+func TestPublicKeyFromPEM(t *testing.T) {
+	s, err := FromPath("../test/signer.json")
+	require.NoError(t, err)
+	spki, err := x509.MarshalPKIXPublicKey(s.PublicKey)
+	require.NoError(t, err)
+
+	publicKey, err := crypto.GetPublicKeyFromPEM(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: spki}))
+	require.NoError(t, err)
+	assert.Equal(t, s.Owner(), crypto.GetOwnerFromPublicKey(publicKey))
+	assert.Equal(t, s.Address, crypto.GetAddressFromPublicKey(publicKey))
+}
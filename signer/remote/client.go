@@ -0,0 +1,246 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/tag"
+	"github.com/liteseed/goar/transaction/data_item"
+)
+
+// DEFAULT_TIMEOUT is the request timeout of the default HTTP client.
+const DEFAULT_TIMEOUT = 30 * time.Second
+
+// Options configures a Client.
+type Options struct {
+	HMACKey    []byte       // Shared HMAC key (nil when the server authenticates with mutual TLS)
+	HTTPClient *http.Client // HTTP client, e.g. with a TLS client certificate (defaults to one with DEFAULT_TIMEOUT)
+}
+
+// Client signs through a remote signing server. It implements
+// signer.Interface.
+//
+// Every signature returned by the server is verified against the owner
+// before it is used, so a misbehaving server cannot produce invalid
+// transactions.
+//
+// A Client is safe for concurrent use.
+type Client struct {
+	url     string
+	hmacKey []byte
+	http    *http.Client
+	owner   string
+	address string
+}
+
+// Dial connects to a signing server and fetches the owner of its key.
+//
+// Parameters:
+//   - ctx: Context for the owner request
+//   - url: Base URL of the server (e.g. "https://signer.internal:8443")
+//   - opts: Authentication and transport options (can be nil for mutual TLS with the default client)
+//
+// Returns the Client, ErrUnauthorized if the server rejects the credentials,
+// or an error if the server cannot be reached or returns an invalid owner.
+//
+// Example:
+//
+//	rs, err := remote.Dial(ctx, "https://signer.internal:8443", &remote.Options{HMACKey: key})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Printf("Signing as %s\n", rs.Address())
+func Dial(ctx context.Context, url string, opts *Options) (*Client, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	c := &Client{
+		url:     strings.TrimRight(url, "/"),
+		hmacKey: opts.HMACKey,
+		http:    opts.HTTPClient,
+	}
+	if c.http == nil {
+		c.http = &http.Client{Timeout: DEFAULT_TIMEOUT}
+	}
+
+	res := &OwnerResponse{}
+	if err := c.do(ctx, http.MethodGet, OWNER_PATH, nil, res); err != nil {
+		return nil, err
+	}
+	address, err := crypto.GetAddressFromOwner(res.Owner)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: invalid owner: %w", err)
+	}
+	if address != res.Address {
+		return nil, errors.New("remote signer: address does not match the owner")
+	}
+	c.owner, c.address = res.Owner, res.Address
+	return c, nil
+}
+
+// Owner returns the base64url-encoded public key modulus of the remote key.
+func (c *Client) Owner() string {
+	return c.owner
+}
+
+// Address returns the wallet address of the remote key.
+func (c *Client) Address() string {
+	return c.address
+}
+
+// Sign signs a deep hash digest with the remote key.
+//
+// This method implements signer.Interface; use SignContext to control the
+// request deadline.
+func (c *Client) Sign(data []byte) ([]byte, error) {
+	return c.SignContext(context.Background(), data)
+}
+
+// SignContext signs a deep hash digest with the remote key.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - digest: The DIGEST_SIZE-byte deep hash to sign
+//
+// Returns the verified signature, or an error if the request fails or the
+// signature is invalid.
+func (c *Client) SignContext(ctx context.Context, digest []byte) ([]byte, error) {
+	if len(digest) != DIGEST_SIZE {
+		return nil, fmt.Errorf("remote signer: digest must be %d bytes, got %d", DIGEST_SIZE, len(digest))
+	}
+	res := &SignResponse{}
+	if err := c.do(ctx, http.MethodPost, SIGN_PATH, &SignRequest{Digest: crypto.Base64URLEncode(digest)}, res); err != nil {
+		return nil, err
+	}
+	signature, err := crypto.Base64URLDecode(res.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: invalid signature: %w", err)
+	}
+	publicKey, err := crypto.GetPublicKeyFromOwner(c.owner)
+	if err != nil {
+		return nil, err
+	}
+	if err = crypto.Verify(digest, signature, publicKey); err != nil {
+		return nil, fmt.Errorf("remote signer: invalid signature: %w", err)
+	}
+	return signature, nil
+}
+
+// SignDataItem has the server build and sign a data item.
+//
+// Unlike d.Sign(c), which only sends the digest, the server receives the
+// target, anchor, tags and data, so it can see what it signs. The data item
+// returned by the server must match d and carry a valid signature; d is then
+// replaced by the signed data item.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - d: The unsigned data item
+//
+// Returns an error if the request fails or the server returns a data item
+// that does not match.
+//
+// Example:
+//
+//	d := data_item.New(data, "", "", &tags)
+//	if err := rs.SignDataItem(ctx, d); err != nil {
+//		log.Fatal(err)
+//	}
+func (c *Client) SignDataItem(ctx context.Context, d *data_item.DataItem) error {
+	var tags []tag.Tag
+	if d.Tags != nil {
+		tags = *d.Tags
+	}
+	req := &SignDataItemRequest{Target: d.Target, Anchor: d.Anchor, Tags: tags, Data: d.Data}
+	res := &SignDataItemResponse{}
+	if err := c.do(ctx, http.MethodPost, SIGN_DATA_ITEM_PATH, req, res); err != nil {
+		return err
+	}
+
+	raw, err := crypto.Base64URLDecode(res.Raw)
+	if err != nil {
+		return fmt.Errorf("remote signer: invalid data item: %w", err)
+	}
+	signed, err := data_item.Decode(raw)
+	if err != nil {
+		return fmt.Errorf("remote signer: invalid data item: %w", err)
+	}
+	if signed.Owner != c.owner || signed.Target != d.Target || signed.Anchor != d.Anchor || signed.Data != d.Data || !equalTags(tags, signed.Tags) {
+		return errors.New("remote signer: signed data item does not match the request")
+	}
+	if err = signed.Verify(); err != nil {
+		return fmt.Errorf("remote signer: invalid data item: %w", err)
+	}
+	*d = *signed
+	return nil
+}
+
+// do sends an authenticated request and decodes the JSON response into res.
+func (c *Client) do(ctx context.Context, method string, route string, payload any, res any) error {
+	var body []byte
+	if payload != nil {
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.url+route, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if len(c.hmacKey) > 0 {
+		if err = setAuth(req, c.hmacKey, route, body, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		e := &errorResponse{}
+		if json.Unmarshal(b, e) != nil || e.Error == "" {
+			e.Error = http.StatusText(resp.StatusCode)
+		}
+		if resp.StatusCode == http.StatusUnauthorized {
+			return fmt.Errorf("%w: %s", ErrUnauthorized, e.Error)
+		}
+		return fmt.Errorf("remote signer: %s (status %d)", e.Error, resp.StatusCode)
+	}
+	return json.Unmarshal(b, res)
+}
+
+// equalTags reports whether the tags of a signed data item match the request.
+func equalTags(expected []tag.Tag, actual *[]tag.Tag) bool {
+	var tags []tag.Tag
+	if actual != nil {
+		tags = *actual
+	}
+	if len(expected) != len(tags) {
+		return false
+	}
+	for i := range expected {
+		if expected[i] != tags[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+// Package remote lets services sign Arweave transactions and data items
+// without holding the wallet key.
+//
+// A Server wraps a signer.Signer and exposes it over a small HTTP/JSON
+// protocol. A Client talks to the server and implements signer.Interface, so
+// it can be passed to Transaction.Sign, DataItem.Sign and receipt signing in
+// place of a local signer.
+//
+// The protocol has three endpoints:
+//
+//	GET  /v1/owner           -> {"owner": "...", "address": "..."}
+//	POST /v1/sign            {"digest": "..."} -> {"signature": "..."}
+//	POST /v1/sign/data-item  {"target", "anchor", "tags", "data"} -> {"raw": "..."}
+//
+// Binary values are base64url-encoded. /v1/sign only signs 48-byte deep hash
+// digests; /v1/sign/data-item builds and signs the data item on the server,
+// so the server sees exactly what it signs. Errors are returned as
+// {"error": "..."} with a 4xx or 5xx status.
+//
+// Requests are authenticated either with a shared HMAC key, or with mutual
+// TLS when the server has no HMAC key. With HMAC, every request carries a
+// Unix timestamp, a random nonce and an HMAC-SHA256 of the method, route,
+// timestamp, nonce and body. It is rejected when the timestamp is more than
+// MAX_CLOCK_SKEW away from the server clock, or when the server already
+// accepted its nonce, so a captured request cannot be replayed.
+//
+// Example usage:
+//
+//	// Signing host
+//	s, err := signer.FromEncryptedPath("wallet.keystore.json", passphrase)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	log.Fatal(http.ListenAndServeTLS(":8443", "cert.pem", "key.pem", remote.NewServer(s, key)))
+//
+//	// Service
+//	rs, err := remote.Dial(ctx, "https://signer.internal:8443", &remote.Options{HMACKey: key})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	err = tx.Sign(rs)
+package remote
+
+import (
+	"errors"
+	"time"
+
+	"github.com/liteseed/goar/tag"
+)
+
+// Protocol routes.
+const (
+	OWNER_PATH          = "/v1/owner"
+	SIGN_PATH           = "/v1/sign"
+	SIGN_DATA_ITEM_PATH = "/v1/sign/data-item"
+)
+
+// HMAC authentication headers.
+const (
+	TIMESTAMP_HEADER = "X-Goar-Timestamp" // Unix time of the request in seconds
+	NONCE_HEADER     = "X-Goar-Nonce"     // Hex-encoded random 16 bytes, unique per request
+	SIGNATURE_HEADER = "X-Goar-Signature" // Hex-encoded HMAC-SHA256 of the request
+)
+
+// MAX_CLOCK_SKEW is how far the timestamp of an HMAC-authenticated request
+// can be from the server clock.
+const MAX_CLOCK_SKEW = 5 * time.Minute
+
+// DIGEST_SIZE is the size of the deep hash digests accepted by /v1/sign.
+const DIGEST_SIZE = 48
+
+// ErrUnauthorized is returned by the Client when the server rejects its
+// credentials.
+var ErrUnauthorized = errors.New("remote signer: unauthorized")
+
+// OwnerResponse is the response of OWNER_PATH.
+type OwnerResponse struct {
+	Owner   string `json:"owner"`   // Base64url-encoded public key modulus
+	Address string `json:"address"` // Wallet address
+}
+
+// SignRequest is the request of SIGN_PATH.
+type SignRequest struct {
+	Digest string `json:"digest"` // Base64url-encoded deep hash to sign
+}
+
+// SignResponse is the response of SIGN_PATH.
+type SignResponse struct {
+	Signature string `json:"signature"` // Base64url-encoded RSA-PSS signature
+}
+
+// SignDataItemRequest is the request of SIGN_DATA_ITEM_PATH.
+type SignDataItemRequest struct {
+	Target string    `json:"target"` // Target address (can be empty)
+	Anchor string    `json:"anchor"` // Anchor (can be empty)
+	Tags   []tag.Tag `json:"tags"`   // Tags in plain text
+	Data   string    `json:"data"`   // Base64url-encoded data
+}
+
+// SignDataItemResponse is the response of SIGN_DATA_ITEM_PATH.
+type SignDataItemResponse struct {
+	Raw string `json:"raw"` // Base64url-encoded signed data item
+}
+
+// errorResponse is the body of error responses.
+type errorResponse struct {
+	Error string `json:"error"`
+}
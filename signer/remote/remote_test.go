@@ -0,0 +1,294 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/currency"
+	"github.com/liteseed/goar/signer"
+	"github.com/liteseed/goar/tag"
+	"github.com/liteseed/goar/transaction"
+	"github.com/liteseed/goar/transaction/data_item"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testKey = []byte("shared secret")
+
+// The remote client can be used wherever a signer is expected
+var _ signer.Interface = (*Client)(nil)
+
+// newHMACServer starts an in-process signing server with HMAC authentication
+func newHMACServer(t *testing.T) (*signer.Signer, *httptest.Server) {
+	s, err := signer.FromPath("../../test/signer.json")
+	require.NoError(t, err)
+	srv := httptest.NewServer(NewServer(s, testKey))
+	t.Cleanup(srv.Close)
+	return s, srv
+}
+
+// TestDial verifies the client fetches the owner and address of the remote key
+func TestDial(t *testing.T) {
+	s, srv := newHMACServer(t)
+
+	c, err := Dial(context.Background(), srv.URL, &Options{HMACKey: testKey})
+	require.NoError(t, err)
+	assert.Equal(t, s.Owner(), c.Owner())
+	assert.Equal(t, s.Address, c.Address())
+}
+
+// TestDialUnauthorized verifies requests with a wrong or missing key are rejected
+func TestDialUnauthorized(t *testing.T) {
+	_, srv := newHMACServer(t)
+
+	_, err := Dial(context.Background(), srv.URL, &Options{HMACKey: []byte("wrong")})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	_, err = Dial(context.Background(), srv.URL, nil)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+// TestSignTransaction verifies a transaction signed remotely is valid
+func TestSignTransaction(t *testing.T) {
+	_, srv := newHMACServer(t)
+	c, err := Dial(context.Background(), srv.URL, &Options{HMACKey: testKey})
+	require.NoError(t, err)
+
+	tx := transaction.New([]byte("remote"), "", currency.Zero, nil)
+	tx.Owner = c.Owner()
+	tx.LastTx = "anchor"
+	tx.Reward = currency.NewWinston(1000)
+	require.NoError(t, tx.Sign(c))
+	assert.NoError(t, tx.Verify())
+}
+
+// TestSignDataItemDigest verifies a data item signed through the digest endpoint is valid
+func TestSignDataItemDigest(t *testing.T) {
+	_, srv := newHMACServer(t)
+	c, err := Dial(context.Background(), srv.URL, &Options{HMACKey: testKey})
+	require.NoError(t, err)
+
+	d := data_item.New([]byte("remote"), "", "", &[]tag.Tag{{Name: "App-Name", Value: "goar"}})
+	require.NoError(t, d.Sign(c))
+	assert.NoError(t, d.Verify())
+	assert.Equal(t, c.Owner(), d.Owner)
+}
+
+// TestSignDataItem verifies the server builds and signs the requested data item
+func TestSignDataItem(t *testing.T) {
+	s, srv := newHMACServer(t)
+	c, err := Dial(context.Background(), srv.URL, &Options{HMACKey: testKey})
+	require.NoError(t, err)
+
+	tags := []tag.Tag{{Name: "Content-Type", Value: "text/plain"}}
+	d := data_item.New([]byte("hello"), s.Address, "", &tags)
+	require.NoError(t, c.SignDataItem(context.Background(), d))
+	assert.NotEmpty(t, d.ID)
+	assert.NotEmpty(t, d.Raw)
+	assert.Equal(t, s.Address, d.Target)
+	assert.Equal(t, tags, *d.Tags)
+	assert.NoError(t, d.Verify())
+
+	decoded, err := data_item.Decode(d.Raw)
+	require.NoError(t, err)
+	assert.Equal(t, d.ID, decoded.ID)
+}
+
+// TestSignRejectsNonDigest verifies the server only signs deep hash digests
+func TestSignRejectsNonDigest(t *testing.T) {
+	_, srv := newHMACServer(t)
+	c, err := Dial(context.Background(), srv.URL, &Options{HMACKey: testKey})
+	require.NoError(t, err)
+
+	_, err = c.Sign([]byte("arbitrary message"))
+	assert.Error(t, err)
+
+	// Bypass the client check to make sure the server enforces it too
+	res := &SignResponse{}
+	err = c.do(context.Background(), http.MethodPost, SIGN_PATH, &SignRequest{Digest: crypto.Base64URLEncode([]byte("short"))}, res)
+	assert.ErrorContains(t, err, "status 400")
+}
+
+// TestSignVerifiesServerSignature verifies signatures from a key other than the owner are rejected
+func TestSignVerifiesServerSignature(t *testing.T) {
+	_, srv := newHMACServer(t)
+	c, err := Dial(context.Background(), srv.URL, &Options{HMACKey: testKey})
+	require.NoError(t, err)
+
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	c.owner = signer.FromPrivateKey(other).Owner()
+
+	_, err = c.Sign(make([]byte, DIGEST_SIZE))
+	assert.ErrorContains(t, err, "invalid signature")
+}
+
+// TestServerRejectsStaleTimestamp verifies HMAC requests outside the clock skew are rejected
+func TestServerRejectsStaleTimestamp(t *testing.T) {
+	s, err := signer.FromPath("../../test/signer.json")
+	require.NoError(t, err)
+	server := NewServer(s, testKey)
+
+	body := []byte(`{"digest":"` + crypto.Base64URLEncode(make([]byte, DIGEST_SIZE)) + `"}`)
+	for _, tt := range []struct {
+		name   string
+		age    time.Duration
+		status int
+	}{
+		{"fresh", 0, http.StatusOK},
+		{"skewed", MAX_CLOCK_SKEW / 2, http.StatusOK},
+		{"stale", 2 * MAX_CLOCK_SKEW, http.StatusUnauthorized},
+		{"future", -2 * MAX_CLOCK_SKEW, http.StatusUnauthorized},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, SIGN_PATH, bytes.NewReader(body))
+			require.NoError(t, setAuth(req, testKey, SIGN_PATH, body, time.Now().Add(-tt.age)))
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+
+	// A valid MAC over a different body is rejected
+	req := httptest.NewRequest(http.MethodPost, SIGN_PATH, bytes.NewReader(body))
+	require.NoError(t, setAuth(req, testKey, SIGN_PATH, []byte("{}"), time.Now()))
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+// TestServerRejectsReplay verifies an HMAC request is only accepted once within the clock skew
+func TestServerRejectsReplay(t *testing.T) {
+	s, err := signer.FromPath("../../test/signer.json")
+	require.NoError(t, err)
+	server := NewServer(s, testKey)
+	now := time.Now()
+	server.now = func() time.Time { return now }
+
+	body := []byte(`{"digest":"` + crypto.Base64URLEncode(make([]byte, DIGEST_SIZE)) + `"}`)
+	send := func(header http.Header) int {
+		req := httptest.NewRequest(http.MethodPost, SIGN_PATH, bytes.NewReader(body))
+		req.Header = header.Clone()
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	req := httptest.NewRequest(http.MethodPost, SIGN_PATH, bytes.NewReader(body))
+	require.NoError(t, setAuth(req, testKey, SIGN_PATH, body, now))
+	assert.Equal(t, http.StatusOK, send(req.Header))
+	assert.Equal(t, http.StatusUnauthorized, send(req.Header), "replayed")
+
+	// Once the timestamp is outside the clock skew, the request is rejected as stale
+	now = now.Add(MAX_CLOCK_SKEW + 2*time.Second)
+	assert.Equal(t, http.StatusUnauthorized, send(req.Header), "stale")
+
+	// A new request with the same body is accepted
+	fresh := httptest.NewRequest(http.MethodPost, SIGN_PATH, bytes.NewReader(body))
+	require.NoError(t, setAuth(fresh, testKey, SIGN_PATH, body, now))
+	assert.Equal(t, http.StatusOK, send(fresh.Header))
+
+	// A request without a nonce is rejected
+	header := fresh.Header.Clone()
+	header.Del(NONCE_HEADER)
+	assert.Equal(t, http.StatusUnauthorized, send(header))
+}
+
+// TestServerRoutes verifies unknown routes and methods are rejected
+func TestServerRoutes(t *testing.T) {
+	s, err := signer.FromPath("../../test/signer.json")
+	require.NoError(t, err)
+	server := NewServer(s, testKey)
+
+	for _, tt := range []struct {
+		method string
+		path   string
+		status int
+	}{
+		{http.MethodGet, "/v1/unknown", http.StatusNotFound},
+		{http.MethodPost, OWNER_PATH, http.StatusMethodNotAllowed},
+		{http.MethodGet, SIGN_PATH, http.StatusMethodNotAllowed},
+	} {
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+		assert.Equal(t, tt.status, rec.Code, tt.method+" "+tt.path)
+	}
+}
+
+// TestMutualTLS verifies a server without an HMAC key requires a verified client certificate
+func TestMutualTLS(t *testing.T) {
+	s, err := signer.FromPath("../../test/signer.json")
+	require.NoError(t, err)
+	caCert, caKey := newCA(t)
+	clientCert := newClientCertificate(t, caCert, caKey)
+
+	srv := httptest.NewUnstartedServer(NewServer(s, nil))
+	pool := x509.NewCertPool()
+	pool.AddCert(caCert)
+	srv.TLS = &tls.Config{ClientAuth: tls.VerifyClientCertIfGiven, ClientCAs: pool}
+	srv.StartTLS()
+	defer srv.Close()
+
+	// Without a client certificate
+	_, err = Dial(context.Background(), srv.URL, &Options{HTTPClient: srv.Client()})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	// With a client certificate signed by the trusted CA
+	transport := srv.Client().Transport.(*http.Transport).Clone()
+	transport.TLSClientConfig.Certificates = []tls.Certificate{clientCert}
+	httpClient := &http.Client{Transport: transport}
+	c, err := Dial(context.Background(), srv.URL, &Options{HTTPClient: httpClient})
+	require.NoError(t, err)
+	_, err = c.Sign(make([]byte, DIGEST_SIZE))
+	assert.NoError(t, err)
+}
+
+// newCA creates a self-signed certificate authority
+func newCA(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key
+}
+
+// newClientCertificate creates a client certificate signed by the CA
+func newClientCertificate(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "test client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
@@ -0,0 +1,183 @@
+package remote
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/signer"
+	"github.com/liteseed/goar/tag"
+	"github.com/liteseed/goar/transaction/data_item"
+)
+
+// DEFAULT_MAX_BODY_SIZE is the default limit on request bodies, which bounds
+// the size of data items the server signs.
+const DEFAULT_MAX_BODY_SIZE = 64 << 20
+
+// Server is the reference signing server. It implements http.Handler.
+//
+// When HMACKey is set every request must carry a valid HMAC and a nonce the
+// server has not seen within MAX_CLOCK_SKEW. Otherwise every
+// request must be made over TLS with a client certificate verified by the
+// server, so the handler must be served with a tls.Config using
+// tls.RequireAndVerifyClientCert.
+//
+// Example:
+//
+//	srv := &http.Server{
+//		Addr:    ":8443",
+//		Handler: remote.NewServer(s, nil),
+//		TLSConfig: &tls.Config{
+//			ClientAuth: tls.RequireAndVerifyClientCert,
+//			ClientCAs:  clientCAs,
+//		},
+//	}
+//	log.Fatal(srv.ListenAndServeTLS("cert.pem", "key.pem"))
+type Server struct {
+	Signer      *signer.Signer // Signer holding the wallet key
+	HMACKey     []byte         // Shared HMAC key (nil to require mutual TLS instead)
+	MaxBodySize int64          // Largest accepted request body in bytes (defaults to DEFAULT_MAX_BODY_SIZE)
+
+	now    func() time.Time
+	nonces nonceCache
+}
+
+// NewServer creates a signing server.
+//
+// Parameters:
+//   - s: The signer holding the wallet key
+//   - hmacKey: The shared HMAC key, or nil to authenticate clients with mutual TLS
+//
+// Returns the Server, ready to be used as an http.Handler.
+func NewServer(s *signer.Signer, hmacKey []byte) *Server {
+	return &Server{
+		Signer:      s,
+		HMACKey:     hmacKey,
+		MaxBodySize: DEFAULT_MAX_BODY_SIZE,
+		now:         time.Now,
+	}
+}
+
+// ServeHTTP authenticates and handles a protocol request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var method string
+	switch r.URL.Path {
+	case OWNER_PATH:
+		method = http.MethodGet
+	case SIGN_PATH, SIGN_DATA_ITEM_PATH:
+		method = http.MethodPost
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if r.Method != method {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	maxBodySize := s.MaxBodySize
+	if maxBodySize <= 0 {
+		maxBodySize = DEFAULT_MAX_BODY_SIZE
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, "request body too large")
+		return
+	}
+
+	if err = s.authenticate(r, body); err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	switch r.URL.Path {
+	case OWNER_PATH:
+		writeJSON(w, &OwnerResponse{Owner: s.Signer.Owner(), Address: s.Signer.Address})
+	case SIGN_PATH:
+		s.sign(w, body)
+	case SIGN_DATA_ITEM_PATH:
+		s.signDataItem(w, body)
+	}
+}
+
+// authenticate checks the HMAC or client certificate of a request.
+func (s *Server) authenticate(r *http.Request, body []byte) error {
+	if len(s.HMACKey) == 0 {
+		return checkClientCertificate(r)
+	}
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+	t := now()
+	nonce, expiry, err := checkAuth(r, s.HMACKey, r.URL.Path, body, t)
+	if err != nil {
+		return err
+	}
+	if !s.nonces.add(nonce, expiry, t) {
+		return errors.New("replayed request")
+	}
+	return nil
+}
+
+// sign handles SIGN_PATH.
+func (s *Server) sign(w http.ResponseWriter, body []byte) {
+	req := &SignRequest{}
+	if err := json.Unmarshal(body, req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	digest, err := crypto.Base64URLDecode(req.Digest)
+	if err != nil || len(digest) != DIGEST_SIZE {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("digest must be %d base64url-encoded bytes", DIGEST_SIZE))
+		return
+	}
+	signature, err := s.Signer.Sign(digest)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "signing failed")
+		return
+	}
+	writeJSON(w, &SignResponse{Signature: crypto.Base64URLEncode(signature)})
+}
+
+// signDataItem handles SIGN_DATA_ITEM_PATH.
+func (s *Server) signDataItem(w http.ResponseWriter, body []byte) {
+	req := &SignDataItemRequest{}
+	if err := json.Unmarshal(body, req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	data, err := crypto.Base64URLDecode(req.Data)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "data must be base64url-encoded")
+		return
+	}
+	tags := req.Tags
+	if tags == nil {
+		tags = []tag.Tag{}
+	}
+
+	d := data_item.New(data, req.Target, req.Anchor, &tags)
+	if err = d.Sign(s.Signer); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, &SignDataItemResponse{Raw: crypto.Base64URLEncode(d.Raw)})
+}
+
+// writeJSON writes a successful JSON response.
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a JSON error response.
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(&errorResponse{Error: message})
+}
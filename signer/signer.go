@@ -26,13 +26,21 @@ package signer
 import (
 	"crypto/rand"
 	"crypto/rsa"
-	"fmt"
 	"os"
 
-	"github.com/everFinance/gojwk"
 	"github.com/liteseed/goar/crypto"
 )
 
+// Interface is implemented by anything that can sign Arweave transactions
+// and data items, such as a local Signer or a remote signing service.
+type Interface interface {
+	// Owner returns the base64url-encoded public key modulus.
+	Owner() string
+	// Sign returns the RSA-PSS (SHA-256) signature of a message, which is
+	// the deep hash of the transaction or data item being signed.
+	Sign(data []byte) ([]byte, error)
+}
+
 // Signer represents an Arweave wallet signer with RSA key pair.
 //
 // A Signer contains the complete cryptographic identity for an Arweave wallet,
@@ -47,8 +55,7 @@ type Signer struct {
 // New creates a new Signer with a randomly generated RSA key pair.
 //
 // This function generates a new 4096-bit RSA key pair suitable for use
-// with the Arweave protocol. The generated key is automatically converted
-// to JWK format and then loaded into a Signer instance.
+// with the Arweave protocol and loads it into a Signer instance.
 //
 // Returns a new Signer instance with a fresh key pair, or an error if
 // key generation fails.
@@ -66,15 +73,7 @@ func New() (*Signer, error) {
 	if err != nil {
 		return nil, err
 	}
-	jwk, err := gojwk.PrivateKey(key)
-	if err != nil {
-		return nil, err
-	}
-	data, err := gojwk.Marshal(jwk)
-	if err != nil {
-		return nil, err
-	}
-	return FromJWK(data)
+	return FromPrivateKey(key), nil
 }
 
 // FromPath creates a Signer from a JWK file on disk.
@@ -126,35 +125,11 @@ func FromPath(path string) (*Signer, error) {
 //	}
 //	fmt.Printf("Loaded wallet: %s\n", signer.Address)
 func FromJWK(b []byte) (*Signer, error) {
-	key, err := gojwk.Unmarshal(b)
-	if err != nil {
-		return nil, err
-	}
-	rsaPublicKey, err := key.DecodePublicKey()
-	if err != nil {
-		return nil, err
-	}
-	publicKey, ok := rsaPublicKey.(*rsa.PublicKey)
-	if !ok {
-		err = fmt.Errorf("public key type error")
-		return nil, err
-	}
-
-	rsaPrivateKey, err := key.DecodePrivateKey()
+	privateKey, err := decodeJWK(b)
 	if err != nil {
 		return nil, err
 	}
-	privateKey, ok := rsaPrivateKey.(*rsa.PrivateKey)
-	if !ok {
-		err = fmt.Errorf("private key type error")
-		return nil, err
-	}
-
-	return &Signer{
-		Address:    crypto.GetAddressFromPublicKey(publicKey),
-		PublicKey:  publicKey,
-		PrivateKey: privateKey,
-	}, nil
+	return FromPrivateKey(privateKey), nil
 }
 
 // FromPrivateKey creates a Signer from an existing RSA private key.
@@ -196,7 +171,19 @@ func FromPrivateKey(privateKey *rsa.PrivateKey) *Signer {
 //	owner := signer.Owner()
 //	fmt.Printf("Transaction owner: %s\n", owner)
 func (s *Signer) Owner() string {
-	return crypto.Base64URLEncode(s.PublicKey.N.Bytes())
+	return crypto.GetOwnerFromPublicKey(s.PublicKey)
+}
+
+// Sign creates an RSA-PSS signature of data with the private key.
+//
+// This method implements Interface using crypto.Sign.
+//
+// Parameters:
+//   - data: The message to sign, usually a deep hash
+//
+// Returns the raw signature bytes, or an error if signing fails.
+func (s *Signer) Sign(data []byte) ([]byte, error) {
+	return crypto.Sign(data, s.PrivateKey)
 }
 
 // Generate creates a new Arweave-compatible RSA private key in JWK format.
@@ -225,13 +212,5 @@ func Generate() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	jwk, err := gojwk.PrivateKey(key)
-	if err != nil {
-		return nil, err
-	}
-	data, err := gojwk.Marshal(jwk)
-	if err != nil {
-		return nil, err
-	}
-	return data, nil
+	return encodeJWK(key)
 }
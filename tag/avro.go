@@ -0,0 +1,147 @@
+package tag
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ANS-104 encodes tags with the Avro schema
+//
+//	{
+//		"type": "array",
+//		"items": {
+//			"type": "record",
+//			"name": "Tag",
+//			"fields": [
+//				{ "name": "name", "type": "bytes" },
+//				{ "name": "value", "type": "bytes" }
+//			]
+//		}
+//	}
+//
+// An array is a sequence of blocks, each a zigzag varint item count followed by
+// the items, and ends with an empty block. A negative count is followed by the
+// block size in bytes. Bytes are a zigzag varint length followed by the
+// content. Zigzag varints are the signed varints of encoding/binary.
+
+// fromAvro converts Avro-encoded binary data to human-readable Tags.
+//
+// This internal function takes Avro-encoded tag data and converts it back
+// to a slice of Tag structs. It accepts any block layout allowed by Avro and
+// enforces the ANS-104 tag limits.
+//
+// Parameters:
+//   - data: The Avro-encoded binary data
+//
+// Returns a slice of Tag structs or an error if the data is malformed, exceeds
+// the tag limits or has trailing bytes.
+func fromAvro(data []byte) (*[]Tag, error) {
+	r := &avroReader{data: data}
+	tags := []Tag{}
+	for {
+		count, err := r.long()
+		if err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			break
+		}
+		if count < 0 {
+			// The block size lets readers skip blocks; it is not needed here
+			if count = -count; count < 0 {
+				return nil, errors.New("avro: invalid block count")
+			}
+			if _, err = r.long(); err != nil {
+				return nil, err
+			}
+		}
+		if count > int64(MAX_TAGS-len(tags)) {
+			return nil, fmt.Errorf("avro: more than %d tags", MAX_TAGS)
+		}
+		for i := int64(0); i < count; i++ {
+			name, err := r.bytes(MAX_TAG_NAME_LENGTH)
+			if err != nil {
+				return nil, fmt.Errorf("avro: tag %d name: %w", len(tags), err)
+			}
+			value, err := r.bytes(MAX_TAG_VALUE_LENGTH)
+			if err != nil {
+				return nil, fmt.Errorf("avro: tag %d value: %w", len(tags), err)
+			}
+			tags = append(tags, Tag{Name: string(name), Value: string(value)})
+		}
+	}
+	if r.position != len(data) {
+		return nil, fmt.Errorf("avro: %d trailing bytes", len(data)-r.position)
+	}
+	return &tags, nil
+}
+
+// toAvro converts human-readable Tags to Avro-encoded binary data.
+//
+// This internal function takes a slice of Tag structs and converts them
+// to Avro-encoded binary data. All tags are written in a single block, as
+// arweave-js does, so the output is byte-for-byte identical.
+//
+// Parameters:
+//   - tags: A slice of Tag structs to encode
+//
+// Returns the Avro-encoded binary data. Encoding cannot fail; the error is
+// always nil.
+func toAvro(tags *[]Tag) ([]byte, error) {
+	size := 2 * binary.MaxVarintLen64
+	for _, tag := range *tags {
+		size += 2*binary.MaxVarintLen64 + len(tag.Name) + len(tag.Value)
+	}
+
+	data := make([]byte, 0, size)
+	if len(*tags) > 0 {
+		data = binary.AppendVarint(data, int64(len(*tags)))
+		for _, tag := range *tags {
+			data = binary.AppendVarint(data, int64(len(tag.Name)))
+			data = append(data, tag.Name...)
+			data = binary.AppendVarint(data, int64(len(tag.Value)))
+			data = append(data, tag.Value...)
+		}
+	}
+	return append(data, 0), nil
+}
+
+// avroReader reads Avro primitives from a byte slice.
+type avroReader struct {
+	data     []byte
+	position int
+}
+
+// long reads a zigzag varint.
+func (r *avroReader) long() (int64, error) {
+	v, n := binary.Varint(r.data[r.position:])
+	if n == 0 {
+		return 0, errors.New("avro: truncated varint")
+	}
+	if n < 0 {
+		return 0, errors.New("avro: varint overflows 64 bits")
+	}
+	r.position += n
+	return v, nil
+}
+
+// bytes reads a length-prefixed byte string of at most limit bytes.
+func (r *avroReader) bytes(limit int) ([]byte, error) {
+	length, err := r.long()
+	if err != nil {
+		return nil, err
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("negative length %d", length)
+	}
+	if length > int64(limit) {
+		return nil, fmt.Errorf("length %d exceeds the limit of %d bytes", length, limit)
+	}
+	if length > int64(len(r.data)-r.position) {
+		return nil, fmt.Errorf("length %d past end of data", length)
+	}
+	b := r.data[r.position : r.position+int(length)]
+	r.position += int(length)
+	return b, nil
+}
@@ -0,0 +1,126 @@
+package tag
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAvroConformance verifies the encoding matches arweave-js byte for byte
+func TestAvroConformance(t *testing.T) {
+	value := strings.Repeat("v", 200)
+	tests := []struct {
+		name     string
+		tags     []Tag
+		expected []byte
+	}{
+		{"empty", []Tag{}, []byte{0}},
+		{"single", []Tag{{Name: "a", Value: "b"}}, []byte{2, 2, 'a', 2, 'b', 0}},
+		// 200 zigzag-encodes to 400, a two byte varint
+		{"multi-byte length", []Tag{{Name: "a", Value: value}}, append(append([]byte{2, 2, 'a', 0x90, 0x03}, value...), 0)},
+		// 64 tags zigzag-encode to 128, a two byte varint
+		{"multi-byte count", make([]Tag, 64), append(append([]byte{0x80, 0x01}, bytes.Repeat([]byte{0, 0}, 64)...), 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := toAvro(&tt.tags)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, data)
+
+			tags, err := fromAvro(data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.tags, *tags)
+		})
+	}
+}
+
+// TestFromAvroBlocks verifies arrays split into several blocks, including blocks with a byte size, are decoded
+func TestFromAvroBlocks(t *testing.T) {
+	data := []byte{
+		2, 2, 'a', 2, 'b', // block of one tag
+		3, 12, 2, 'c', 2, 'd', 2, 'e', 2, 'f', // block of two tags with a size of 6 bytes
+		0,
+	}
+	tags, err := fromAvro(data)
+	require.NoError(t, err)
+	assert.Equal(t, []Tag{{Name: "a", Value: "b"}, {Name: "c", Value: "d"}, {Name: "e", Value: "f"}}, *tags)
+}
+
+// TestFromAvroMalformed verifies malformed and oversized encodings return errors
+func TestFromAvroMalformed(t *testing.T) {
+	long := func(values ...int64) []byte {
+		var b []byte
+		for _, v := range values {
+			b = binary.AppendVarint(b, v)
+		}
+		return b
+	}
+	tests := []struct {
+		name string
+		data []byte
+		err  string
+	}{
+		{"empty input", nil, "truncated varint"},
+		{"missing terminator", []byte{2, 2, 'a', 2, 'b'}, "truncated varint"},
+		{"varint overflow", bytes.Repeat([]byte{0xff}, 11), "overflows"},
+		{"negative length", append(long(1, -1), 0), "negative length"},
+		{"past end", append(long(1, 5), 'a'), "past end"},
+		{"trailing bytes", []byte{0, 0}, "trailing"},
+		{"too many tags", long(MAX_TAGS + 1), "more than 128 tags"},
+		{"invalid block count", long(-1 << 63), "invalid block count"},
+		{"name too long", append([]byte{2, 0x82, 0x10}, make([]byte, 1025)...), "exceeds the limit of 1024"},
+		{"value too long", append([]byte{2, 0, 0x82, 0x30}, make([]byte, 3073)...), "exceeds the limit of 3072"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := fromAvro(tt.data)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+
+	// MAX_TAGS tags across two blocks are accepted, one more is not
+	block := func(n int) []byte {
+		return append(binary.AppendVarint(nil, int64(n)), bytes.Repeat([]byte{0, 0}, n)...)
+	}
+	tags, err := fromAvro(append(append(block(60), block(MAX_TAGS-60)...), 0))
+	require.NoError(t, err)
+	assert.Len(t, *tags, MAX_TAGS)
+	_, err = fromAvro(append(append(block(60), block(MAX_TAGS-59)...), 0))
+	assert.ErrorContains(t, err, "more than 128 tags")
+}
+
+// benchmarkTags returns 16 tags with 64-byte values
+func benchmarkTags() *[]Tag {
+	tags := make([]Tag, 0, 16)
+	for i := 0; i < 16; i++ {
+		tags = append(tags, Tag{Name: "Name-" + strings.Repeat("n", i), Value: strings.Repeat("v", 64)})
+	}
+	return &tags
+}
+
+// BenchmarkSerialize encodes 16 tags
+func BenchmarkSerialize(b *testing.B) {
+	tags := benchmarkTags()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := Serialize(tags); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkFromAvro decodes 16 tags
+func BenchmarkFromAvro(b *testing.B) {
+	data, err := toAvro(benchmarkTags())
+	require.NoError(b, err)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := fromAvro(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
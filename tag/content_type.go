@@ -0,0 +1,94 @@
+package tag
+
+import (
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// extensionTypes covers common web and media extensions that are missing from
+// the minimal MIME tables some systems ship with, so detection does not depend
+// on the host.
+var extensionTypes = map[string]string{
+	".css":   "text/css; charset=utf-8",
+	".csv":   "text/csv; charset=utf-8",
+	".html":  "text/html; charset=utf-8",
+	".htm":   "text/html; charset=utf-8",
+	".ico":   "image/x-icon",
+	".js":    "text/javascript; charset=utf-8",
+	".json":  "application/json",
+	".md":    "text/markdown; charset=utf-8",
+	".mjs":   "text/javascript; charset=utf-8",
+	".mp3":   "audio/mpeg",
+	".mp4":   "video/mp4",
+	".otf":   "font/otf",
+	".svg":   "image/svg+xml",
+	".ttf":   "font/ttf",
+	".txt":   "text/plain; charset=utf-8",
+	".wasm":  "application/wasm",
+	".webm":  "video/webm",
+	".woff":  "font/woff",
+	".woff2": "font/woff2",
+	".zip":   "application/zip",
+}
+
+// DetectContentType returns the MIME type of a file.
+//
+// The type is determined from the file extension when it is known, and by
+// sniffing the first 512 bytes of the content otherwise. The result is never
+// empty; unknown binary content is reported as "application/octet-stream".
+//
+// Parameters:
+//   - path: The file name or path (can be empty)
+//   - data: The file content (can be nil)
+//
+// Returns the detected MIME type.
+//
+// Example:
+//
+//	DetectContentType("index.html", nil)       // "text/html; charset=utf-8"
+//	DetectContentType("", []byte("\x89PNG...")) // "image/png"
+func DetectContentType(path string, data []byte) string {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != "" {
+		if t, ok := extensionTypes[ext]; ok {
+			return t
+		}
+		if t := mime.TypeByExtension(ext); t != "" {
+			return t
+		}
+	}
+	return http.DetectContentType(data)
+}
+
+// WithContentType adds a Content-Type tag detected from path and data.
+//
+// If the tags already contain a Content-Type tag they are returned unchanged.
+// The input slice is not modified.
+//
+// Parameters:
+//   - tags: The existing tags (can be nil)
+//   - path: The file name or path used for extension based detection
+//   - data: The content used for sniffing
+//
+// Returns a new slice of tags that includes a Content-Type tag.
+//
+// Example:
+//
+//	tags := WithContentType(&[]Tag{AppName("MyApp")}, "photo.jpg", data)
+//	dataItem := data_item.New(data, "", "", tags)
+func WithContentType(tags *[]Tag, path string, data []byte) *[]Tag {
+	var result []Tag
+	if tags != nil {
+		if _, ok := Get(tags, CONTENT_TYPE); ok {
+			result = append(result, *tags...)
+			return &result
+		}
+	}
+	result = append(result, ContentType(DetectContentType(path, data)))
+	if tags != nil {
+		result = append(result, *tags...)
+	}
+	return &result
+}
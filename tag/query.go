@@ -0,0 +1,460 @@
+package tag
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Query is a compiled tag filter expression.
+//
+// Expressions are built from the following terms, where names and values are
+// either bare words (letters, digits and any of - _ . : /) or double quoted
+// strings:
+//
+//	Name = "value"     a tag Name exists with exactly this value
+//	Name ^= "prefix"   a tag Name exists whose value starts with prefix
+//	HAS Name           a tag Name exists
+//
+// Terms are combined with NOT, AND and OR (in decreasing precedence) and
+// parentheses. Keywords are case-insensitive.
+//
+// A Query is immutable and safe for concurrent use, so it should be compiled
+// once and reused for every item.
+//
+// Example:
+//
+//	q, err := Compile(`Content-Type ^= "image/" AND (App-Name = ArDrive OR NOT HAS Type)`)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, d := range b.Items {
+//		if q.Match(d.Tags) {
+//			fmt.Println(d.ID)
+//		}
+//	}
+type Query struct {
+	expr string
+	root queryNode
+}
+
+// Compile parses a tag filter expression.
+//
+// Parameters:
+//   - expr: The expression to compile
+//
+// Returns the compiled Query, or an error describing the first syntax error.
+func Compile(expr string) (*Query, error) {
+	tokens, err := lexQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.pos)
+	}
+	return &Query{expr: expr, root: root}, nil
+}
+
+// MustCompile is like Compile but panics if the expression cannot be parsed.
+//
+// It is intended for expressions known at compile time.
+func MustCompile(expr string) *Query {
+	q, err := Compile(expr)
+	if err != nil {
+		panic(fmt.Sprintf("tag: Compile(%q): %v", expr, err))
+	}
+	return q
+}
+
+// String returns the source expression of the query.
+func (q *Query) String() string {
+	return q.expr
+}
+
+// Match reports whether plain tags satisfy the query.
+//
+// Use Match for data items decoded with bundle.Decode or data_item.Decode,
+// whose tag names and values are plain strings. DataItem.MatchTags and
+// Transaction.MatchTags pick the right method for their tag encoding.
+//
+// Parameters:
+//   - tags: The tags to test (can be nil)
+//
+// Returns true if the tags match the query.
+func (q *Query) Match(tags *[]Tag) bool {
+	var t []Tag
+	if tags != nil {
+		t = *tags
+	}
+	return q.root.match(t)
+}
+
+// MatchBase64 reports whether base64url-encoded tags satisfy the query.
+//
+// Use MatchBase64 for transactions, whose tag names and values are
+// base64url-encoded, or call Transaction.MatchTags.
+//
+// Parameters:
+//   - tags: The base64url-encoded tags to test (can be nil)
+//
+// Returns true if the tags match the query, or an error if a tag cannot be decoded.
+func (q *Query) MatchBase64(tags *[]Tag) (bool, error) {
+	if tags == nil {
+		return q.root.match(nil), nil
+	}
+	raw, err := Decode(tags)
+	if err != nil {
+		return false, err
+	}
+	decoded := make([]Tag, len(raw))
+	for i, r := range raw {
+		decoded[i] = Tag{Name: string(r[0]), Value: string(r[1])}
+	}
+	return q.root.match(decoded), nil
+}
+
+// GraphQL converts the query to an Arweave GraphQL tags filter.
+//
+// GraphQL tag filters are a conjunction of per-tag filters, each matching any
+// of a list of values, optionally negated. Queries are therefore convertible
+// when they are an AND of terms of the form:
+//
+//	Name = "a"
+//	Name = "a" OR Name = "b"
+//	NOT (Name = "a" OR Name = "b")
+//
+// Returns the filter, e.g. `[{name: "App-Name", values: ["A", "B"]}]`, or an
+// error if the query uses prefixes, existence checks or other constructs that
+// GraphQL cannot express.
+//
+// Example:
+//
+//	filter, err := q.GraphQL()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	query := fmt.Sprintf(`{ transactions(tags: %s) { edges { node { id } } } }`, filter)
+func (q *Query) GraphQL() (string, error) {
+	var conjuncts []queryNode
+	flattenAnd(q.root, &conjuncts)
+
+	var filters []string
+	for _, c := range conjuncts {
+		negated := false
+		if n, ok := c.(*notNode); ok {
+			negated = true
+			c = n.node
+		}
+		name, values, ok := equalityValues(c)
+		if !ok {
+			return "", fmt.Errorf("query %q cannot be expressed as a GraphQL tags filter", q.expr)
+		}
+		quoted := make([]string, len(values))
+		for i, v := range values {
+			quoted[i] = graphQLString(v)
+		}
+		filter := fmt.Sprintf("{name: %s, values: [%s]", graphQLString(name), strings.Join(quoted, ", "))
+		if negated {
+			filter += ", op: NEQ"
+		}
+		filters = append(filters, filter+"}")
+	}
+	return "[" + strings.Join(filters, ", ") + "]", nil
+}
+
+// flattenAnd collects the operands of nested AND nodes.
+func flattenAnd(n queryNode, out *[]queryNode) {
+	if a, ok := n.(*andNode); ok {
+		flattenAnd(a.left, out)
+		flattenAnd(a.right, out)
+		return
+	}
+	*out = append(*out, n)
+}
+
+// equalityValues returns the tag name and values of an equality term or an
+// OR of equality terms on the same name.
+func equalityValues(n queryNode) (string, []string, bool) {
+	switch n := n.(type) {
+	case *equalNode:
+		return n.name, []string{n.value}, true
+	case *orNode:
+		leftName, leftValues, ok := equalityValues(n.left)
+		if !ok {
+			return "", nil, false
+		}
+		rightName, rightValues, ok := equalityValues(n.right)
+		if !ok || leftName != rightName {
+			return "", nil, false
+		}
+		return leftName, append(leftValues, rightValues...), true
+	}
+	return "", nil, false
+}
+
+// graphQLString quotes s as a GraphQL string literal.
+func graphQLString(s string) string {
+	var b bytes.Buffer
+	e := json.NewEncoder(&b)
+	e.SetEscapeHTML(false)
+	_ = e.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// queryNode is a node of the compiled expression tree.
+type queryNode interface {
+	match(tags []Tag) bool
+}
+
+type equalNode struct{ name, value string }
+
+func (n *equalNode) match(tags []Tag) bool {
+	for _, t := range tags {
+		if t.Name == n.name && t.Value == n.value {
+			return true
+		}
+	}
+	return false
+}
+
+type prefixNode struct{ name, prefix string }
+
+func (n *prefixNode) match(tags []Tag) bool {
+	for _, t := range tags {
+		if t.Name == n.name && strings.HasPrefix(t.Value, n.prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+type hasNode struct{ name string }
+
+func (n *hasNode) match(tags []Tag) bool {
+	for _, t := range tags {
+		if t.Name == n.name {
+			return true
+		}
+	}
+	return false
+}
+
+type notNode struct{ node queryNode }
+
+func (n *notNode) match(tags []Tag) bool { return !n.node.match(tags) }
+
+type andNode struct{ left, right queryNode }
+
+func (n *andNode) match(tags []Tag) bool { return n.left.match(tags) && n.right.match(tags) }
+
+type orNode struct{ left, right queryNode }
+
+func (n *orNode) match(tags []Tag) bool { return n.left.match(tags) || n.right.match(tags) }
+
+// Query tokens
+const (
+	tokenEOF = iota
+	tokenWord
+	tokenString
+	tokenEqual
+	tokenPrefix
+	tokenOpen
+	tokenClose
+)
+
+type queryToken struct {
+	kind int
+	text string // Source text, or the unquoted value for strings
+	pos  int
+}
+
+// isWordByte reports whether c can be part of a bare word.
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '-' || c == '_' || c == '.' || c == ':' || c == '/'
+}
+
+// lexQuery splits an expression into tokens.
+func lexQuery(expr string) ([]queryToken, error) {
+	var tokens []queryToken
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, queryToken{kind: tokenOpen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, queryToken{kind: tokenClose, text: ")", pos: i})
+			i++
+		case c == '=':
+			tokens = append(tokens, queryToken{kind: tokenEqual, text: "=", pos: i})
+			i++
+		case c == '^':
+			if i+1 >= len(expr) || expr[i+1] != '=' {
+				return nil, fmt.Errorf("expected \"^=\" at position %d", i)
+			}
+			tokens = append(tokens, queryToken{kind: tokenPrefix, text: "^=", pos: i})
+			i += 2
+		case c == '"':
+			end := i + 1
+			for end < len(expr) && expr[end] != '"' {
+				if expr[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(expr) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			value, err := strconv.Unquote(expr[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at position %d: %w", i, err)
+			}
+			tokens = append(tokens, queryToken{kind: tokenString, text: value, pos: i})
+			i = end + 1
+		case isWordByte(c):
+			end := i
+			for end < len(expr) && isWordByte(expr[end]) {
+				end++
+			}
+			tokens = append(tokens, queryToken{kind: tokenWord, text: expr[i:end], pos: i})
+			i = end
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+		}
+	}
+	return append(tokens, queryToken{kind: tokenEOF, text: "end of expression", pos: len(expr)}), nil
+}
+
+// queryParser is a recursive descent parser over query tokens.
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.pos]
+}
+
+func (p *queryParser) next() queryToken {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// keyword reports whether the next token is the given keyword and consumes it.
+func (p *queryParser) keyword(k string) bool {
+	t := p.peek()
+	if t.kind == tokenWord && strings.EqualFold(t.text, k) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (queryNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("AND") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseUnary() (queryNode, error) {
+	if p.keyword("NOT") {
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{node: n}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (queryNode, error) {
+	if p.peek().kind == tokenOpen {
+		p.next()
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokenClose {
+			return nil, fmt.Errorf("expected \")\" at position %d, found %q", t.pos, t.text)
+		}
+		return n, nil
+	}
+	if p.keyword("HAS") {
+		name, err := p.operand("tag name")
+		if err != nil {
+			return nil, err
+		}
+		return &hasNode{name: name}, nil
+	}
+
+	name, err := p.operand("tag name")
+	if err != nil {
+		return nil, err
+	}
+	op := p.next()
+	switch op.kind {
+	case tokenEqual:
+		value, err := p.operand("tag value")
+		if err != nil {
+			return nil, err
+		}
+		return &equalNode{name: name, value: value}, nil
+	case tokenPrefix:
+		value, err := p.operand("tag value")
+		if err != nil {
+			return nil, err
+		}
+		return &prefixNode{name: name, prefix: value}, nil
+	}
+	return nil, fmt.Errorf("expected \"=\" or \"^=\" at position %d, found %q", op.pos, op.text)
+}
+
+// operand consumes a bare word or string.
+func (p *queryParser) operand(what string) (string, error) {
+	t := p.next()
+	if t.kind != tokenWord && t.kind != tokenString {
+		return "", fmt.Errorf("expected %s at position %d, found %q", what, t.pos, t.text)
+	}
+	if t.kind == tokenString && t.text == "" {
+		return "", errors.New("empty string at position " + strconv.Itoa(t.pos))
+	}
+	return t.text, nil
+}
@@ -0,0 +1,131 @@
+package tag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryMatch(t *testing.T) {
+	tags := &[]Tag{
+		ContentType("image/png"),
+		AppName("ArDrive"),
+		AppName("Other"),
+		{Name: "File Name", Value: "cat \"1\".png"},
+	}
+
+	testCases := []struct {
+		expr     string
+		expected bool
+	}{
+		{`Content-Type = "image/png"`, true},
+		{`Content-Type = image/png`, true},
+		{`Content-Type = "image/jpeg"`, false},
+		{`Content-Type ^= "image/"`, true},
+		{`Content-Type ^= "text/"`, false},
+		{`App-Name = Other`, true},
+		{`has App-Name`, true},
+		{`HAS Type`, false},
+		{`NOT HAS Type`, true},
+		{`"File Name" = "cat \"1\".png"`, true},
+		{`App-Name = ArDrive AND Content-Type = "text/plain"`, false},
+		{`App-Name = ArDrive and Content-Type = "text/plain" or HAS Content-Type`, true},
+		{`App-Name = ArDrive AND (Content-Type = "text/plain" OR HAS Type)`, false},
+		{`NOT App-Name = ArDrive OR Content-Type ^= image`, true},
+		{`NOT (App-Name = ArDrive OR Content-Type ^= image)`, false},
+		{`NOT NOT HAS Content-Type`, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			q, err := Compile(tc.expr)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, q.Match(tags))
+		})
+	}
+}
+
+func TestQueryMatchNil(t *testing.T) {
+	assert.False(t, MustCompile(`HAS Type`).Match(nil))
+	assert.True(t, MustCompile(`NOT HAS Type`).Match(nil))
+}
+
+func TestQueryMatchBase64(t *testing.T) {
+	q := MustCompile(`Content-Type = "text/plain" AND NOT HAS Type`)
+
+	tags := ConvertToBase64(&[]Tag{ContentType("text/plain")})
+	ok, err := q.MatchBase64(tags)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	tags = ConvertToBase64(&[]Tag{ContentType("text/plain"), Type("Message")})
+	ok, err = q.MatchBase64(tags)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = q.MatchBase64(&[]Tag{{Name: "!!", Value: "!!"}})
+	assert.Error(t, err)
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, expr := range []string{
+		``,
+		`Content-Type`,
+		`Content-Type =`,
+		`Content-Type == "a"`,
+		`Content-Type ^ "a"`,
+		`Content-Type = "a`,
+		`Content-Type = ""`,
+		`(HAS Type`,
+		`HAS Type)`,
+		`HAS Type AND`,
+		`HAS Type Type`,
+		`Content-Type = a $`,
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := Compile(expr)
+			assert.Error(t, err)
+		})
+	}
+	assert.Panics(t, func() { MustCompile(`(`) })
+}
+
+func TestQueryGraphQL(t *testing.T) {
+	testCases := []struct {
+		expr     string
+		expected string
+	}{
+		{`Content-Type = "text/plain"`, `[{name: "Content-Type", values: ["text/plain"]}]`},
+		{
+			`App-Name = A OR App-Name = B`,
+			`[{name: "App-Name", values: ["A", "B"]}]`,
+		},
+		{
+			`App-Name = A AND (Type = Message OR Type = Process) AND NOT Content-Type = "text/<b>"`,
+			`[{name: "App-Name", values: ["A"]}, {name: "Type", values: ["Message", "Process"]}, {name: "Content-Type", values: ["text/<b>"], op: NEQ}]`,
+		},
+		{
+			`NOT (Type = A OR Type = B)`,
+			`[{name: "Type", values: ["A", "B"], op: NEQ}]`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			filter, err := MustCompile(tc.expr).GraphQL()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, filter)
+		})
+	}
+
+	for _, expr := range []string{
+		`Content-Type ^= "image/"`,
+		`HAS Type`,
+		`App-Name = A OR Type = B`,
+		`NOT NOT Type = A`,
+		`NOT (Type = A AND App-Name = B)`,
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := MustCompile(expr).GraphQL()
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package tag
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Well-known tag names used across the Arweave ecosystem.
+const (
+	CONTENT_TYPE   = "Content-Type"   // MIME type of the data
+	APP_NAME       = "App-Name"       // Name of the application that created the data
+	APP_VERSION    = "App-Version"    // Version of the application that created the data
+	UNIX_TIME      = "Unix-Time"      // Creation time in seconds since the Unix epoch
+	BUNDLE_FORMAT  = "Bundle-Format"  // Bundle encoding, "binary" for ANS-104
+	BUNDLE_VERSION = "Bundle-Version" // Bundle specification version, "2.0.0" for ANS-104
+	DATA_PROTOCOL  = "Data-Protocol"  // Protocol the data conforms to
+	TYPE           = "Type"           // Application specific type of the data
+)
+
+// ANS-104 tag limits.
+const (
+	MAX_TAGS             = 128  // Maximum number of tags on a data item
+	MAX_TAG_NAME_LENGTH  = 1024 // Maximum size of a tag name in bytes
+	MAX_TAG_VALUE_LENGTH = 3072 // Maximum size of a tag value in bytes
+)
+
+// ContentType creates a Content-Type tag.
+//
+// Example:
+//
+//	tags := []Tag{ContentType("application/json")}
+func ContentType(value string) Tag {
+	return Tag{Name: CONTENT_TYPE, Value: value}
+}
+
+// AppName creates an App-Name tag.
+//
+// Example:
+//
+//	tags := []Tag{AppName("MyApp"), AppVersion("1.0.0")}
+func AppName(value string) Tag {
+	return Tag{Name: APP_NAME, Value: value}
+}
+
+// AppVersion creates an App-Version tag.
+func AppVersion(value string) Tag {
+	return Tag{Name: APP_VERSION, Value: value}
+}
+
+// UnixTime creates a Unix-Time tag holding t in whole seconds.
+//
+// Example:
+//
+//	tags := []Tag{UnixTime(time.Now())}
+func UnixTime(t time.Time) Tag {
+	return Tag{Name: UNIX_TIME, Value: strconv.FormatInt(t.Unix(), 10)}
+}
+
+// BundleFormat creates a Bundle-Format tag.
+//
+// Example:
+//
+//	tags := []Tag{BundleFormat("binary"), BundleVersion("2.0.0")}
+func BundleFormat(value string) Tag {
+	return Tag{Name: BUNDLE_FORMAT, Value: value}
+}
+
+// BundleVersion creates a Bundle-Version tag.
+func BundleVersion(value string) Tag {
+	return Tag{Name: BUNDLE_VERSION, Value: value}
+}
+
+// DataProtocol creates a Data-Protocol tag.
+func DataProtocol(value string) Tag {
+	return Tag{Name: DATA_PROTOCOL, Value: value}
+}
+
+// Type creates a Type tag.
+func Type(value string) Tag {
+	return Tag{Name: TYPE, Value: value}
+}
+
+// Validate checks tags against the ANS-104 limits.
+//
+// The following rules are enforced:
+//   - At most MAX_TAGS tags
+//   - Names must be non-empty and at most MAX_TAG_NAME_LENGTH bytes
+//   - Values must be non-empty and at most MAX_TAG_VALUE_LENGTH bytes
+//
+// Parameters:
+//   - tags: The tags to validate (can be nil)
+//
+// Returns nil if the tags are valid, or an error describing the first violation.
+//
+// Learn more: https://github.com/ArweaveTeam/arweave-standards/blob/master/ans/ANS-104.md
+//
+// Example:
+//
+//	if err := Validate(&tags); err != nil {
+//		log.Fatal(err)
+//	}
+func Validate(tags *[]Tag) error {
+	if tags == nil {
+		return nil
+	}
+	if len(*tags) > MAX_TAGS {
+		return fmt.Errorf("tags cannot be more than %d", MAX_TAGS)
+	}
+	for i, t := range *tags {
+		if len(t.Name) == 0 {
+			return fmt.Errorf("tag %d: name is empty", i)
+		}
+		if len(t.Name) > MAX_TAG_NAME_LENGTH {
+			return fmt.Errorf("tag %d: name longer than %d bytes", i, MAX_TAG_NAME_LENGTH)
+		}
+		if len(t.Value) == 0 {
+			return fmt.Errorf("tag %d: value is empty", i)
+		}
+		if len(t.Value) > MAX_TAG_VALUE_LENGTH {
+			return fmt.Errorf("tag %d: value longer than %d bytes", i, MAX_TAG_VALUE_LENGTH)
+		}
+	}
+	return nil
+}
+
+// Get returns the value of the first tag with the given name.
+//
+// Returns the value and true if the tag exists, or an empty string and false otherwise.
+//
+// Example:
+//
+//	contentType, ok := Get(dataItem.Tags, CONTENT_TYPE)
+func Get(tags *[]Tag, name string) (string, bool) {
+	if tags == nil {
+		return "", false
+	}
+	for _, t := range *tags {
+		if t.Name == name {
+			return t.Value, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,109 @@
+package tag
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStandardTags(t *testing.T) {
+	assert.Equal(t, Tag{Name: "Content-Type", Value: "text/plain"}, ContentType("text/plain"))
+	assert.Equal(t, Tag{Name: "App-Name", Value: "MyApp"}, AppName("MyApp"))
+	assert.Equal(t, Tag{Name: "App-Version", Value: "1.0.0"}, AppVersion("1.0.0"))
+	assert.Equal(t, Tag{Name: "Unix-Time", Value: "1700000000"}, UnixTime(time.Unix(1700000000, 999)))
+	assert.Equal(t, Tag{Name: "Bundle-Format", Value: "binary"}, BundleFormat("binary"))
+	assert.Equal(t, Tag{Name: "Bundle-Version", Value: "2.0.0"}, BundleVersion("2.0.0"))
+	assert.Equal(t, Tag{Name: "Data-Protocol", Value: "ao"}, DataProtocol("ao"))
+	assert.Equal(t, Tag{Name: "Type", Value: "Message"}, Type("Message"))
+}
+
+func TestValidate(t *testing.T) {
+	tooMany := make([]Tag, MAX_TAGS+1)
+	for i := range tooMany {
+		tooMany[i] = Tag{Name: "a", Value: "b"}
+	}
+
+	testCases := []struct {
+		name  string
+		tags  *[]Tag
+		valid bool
+	}{
+		{"Nil", nil, true},
+		{"Empty", &[]Tag{}, true},
+		{"Valid", &[]Tag{ContentType("text/plain"), AppName("MyApp")}, true},
+		{"Max tags", func() *[]Tag { t := tooMany[:MAX_TAGS]; return &t }(), true},
+		{"Too many tags", &tooMany, false},
+		{"Empty name", &[]Tag{{Name: "", Value: "v"}}, false},
+		{"Empty value", &[]Tag{{Name: "n", Value: ""}}, false},
+		{"Max name", &[]Tag{{Name: strings.Repeat("n", MAX_TAG_NAME_LENGTH), Value: "v"}}, true},
+		{"Name too long", &[]Tag{{Name: strings.Repeat("n", MAX_TAG_NAME_LENGTH+1), Value: "v"}}, false},
+		{"Max value", &[]Tag{{Name: "n", Value: strings.Repeat("v", MAX_TAG_VALUE_LENGTH)}}, true},
+		{"Value too long", &[]Tag{{Name: "n", Value: strings.Repeat("v", MAX_TAG_VALUE_LENGTH+1)}}, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.tags)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestDetectContentType(t *testing.T) {
+	testCases := []struct {
+		name     string
+		path     string
+		data     []byte
+		expected string
+	}{
+		{"HTML extension", "index.html", nil, "text/html; charset=utf-8"},
+		{"Upper case extension", "STYLE.CSS", nil, "text/css; charset=utf-8"},
+		{"Nested path", "assets/fonts/a.woff2", nil, "font/woff2"},
+		{"Sniff PNG", "", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR"), "image/png"},
+		{"Sniff text", "README", []byte("hello world"), "text/plain; charset=utf-8"},
+		{"Unknown binary", "blob", []byte{0, 1, 2, 3}, "application/octet-stream"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, DetectContentType(tc.path, tc.data))
+		})
+	}
+}
+
+func TestWithContentType(t *testing.T) {
+	t.Run("Adds Content-Type", func(t *testing.T) {
+		tags := &[]Tag{AppName("MyApp")}
+		result := WithContentType(tags, "index.html", nil)
+		assert.Equal(t, []Tag{ContentType("text/html; charset=utf-8"), AppName("MyApp")}, *result)
+		assert.Len(t, *tags, 1)
+	})
+
+	t.Run("Keeps existing Content-Type", func(t *testing.T) {
+		tags := &[]Tag{ContentType("application/json")}
+		result := WithContentType(tags, "index.html", nil)
+		assert.Equal(t, *tags, *result)
+	})
+
+	t.Run("Nil tags", func(t *testing.T) {
+		result := WithContentType(nil, "a.txt", nil)
+		assert.Equal(t, []Tag{ContentType("text/plain; charset=utf-8")}, *result)
+	})
+}
+
+func TestGet(t *testing.T) {
+	tags := &[]Tag{ContentType("text/plain"), AppName("A"), AppName("B")}
+	v, ok := Get(tags, APP_NAME)
+	assert.True(t, ok)
+	assert.Equal(t, "A", v)
+
+	_, ok = Get(tags, TYPE)
+	assert.False(t, ok)
+
+	_, ok = Get(nil, TYPE)
+	assert.False(t, ok)
+}
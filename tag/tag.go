@@ -1,8 +1,8 @@
 // Package tag provides functionality for creating and managing Arweave transaction tags.
 //
-// This package handles tag encoding/decoding operations using Apache Avro format
-// as specified in the Arweave protocol and ANS-104 standard. Tags are key-value
-// pairs that attach metadata to transactions and data items.
+// This package handles tag encoding/decoding operations using the Apache Avro
+// encoding specified by the ANS-104 standard. Tags are key-value pairs that
+// attach metadata to transactions and data items.
 //
 // Example usage:
 //
@@ -25,85 +25,11 @@ package tag
 import (
 	"encoding/binary"
 	"errors"
+	"fmt"
 
-	"github.com/linkedin/goavro/v2"
 	"github.com/liteseed/goar/crypto"
 )
 
-// avroTagSchema defines the Apache Avro schema for encoding tags.
-// This schema is used by both Arweave transactions and ANS-104 data items.
-const avroTagSchema = `
-{
-	"type": "array",
-	"items": {
-		"type": "record",
-		"name": "Tag",
-		"fields": [
-			{ "name": "name", "type": "bytes" },
-			{ "name": "value", "type": "bytes" }
-		]
-	}
-}`
-
-// fromAvro converts Avro-encoded binary data to human-readable Tags.
-//
-// This internal function takes Avro-encoded tag data and converts it back
-// to a slice of Tag structs. It uses the standard Avro schema defined
-// in the Arweave protocol.
-//
-// Parameters:
-//   - data: The Avro-encoded binary data
-//
-// Returns a slice of Tag structs or an error if decoding fails.
-func fromAvro(data []byte) (*[]Tag, error) {
-	codec, err := goavro.NewCodec(avroTagSchema)
-	if err != nil {
-		return nil, err
-	}
-
-	avroTags, _, err := codec.NativeFromBinary(data)
-	if err != nil {
-		return nil, err
-	}
-
-	var tags []Tag
-
-	for _, v := range avroTags.([]any) {
-		tag := v.(map[string]any)
-		tags = append(tags, Tag{Name: string(tag["name"].([]byte)), Value: string(tag["value"].([]byte))})
-	}
-	return &tags, err
-}
-
-// toAvro converts human-readable Tags to Avro-encoded binary data.
-//
-// This internal function takes a slice of Tag structs and converts them
-// to Avro-encoded binary data using the standard schema. The encoded
-// data can be included in transactions or data items.
-//
-// Parameters:
-//   - tags: A slice of Tag structs to encode
-//
-// Returns the Avro-encoded binary data or an error if encoding fails.
-func toAvro(tags *[]Tag) ([]byte, error) {
-	codec, err := goavro.NewCodec(avroTagSchema)
-	if err != nil {
-		return nil, err
-	}
-
-	var avroTags []map[string]any
-
-	for _, tag := range *tags {
-		m := map[string]any{"name": []byte(tag.Name), "value": []byte(tag.Value)}
-		avroTags = append(avroTags, m)
-	}
-	data, err := codec.BinaryFromNative(nil, avroTags)
-	if err != nil {
-		return nil, err
-	}
-	return data, err
-}
-
 // Serialize converts readable Tag data into Avro-encoded bytes for Arweave transactions.
 //
 // This function takes a slice of tags and converts them to the binary format
@@ -151,7 +77,9 @@ func Serialize(tags *[]Tag) ([]byte, error) {
 //   - startAt: The byte offset where tag data begins
 //
 // Returns the parsed tags, the ending offset, and any parsing error.
-// The function enforces the ANS-104 limit of maximum 127 tags per item.
+// The function enforces the ANS-104 limits of MAX_TAGS tags per item and
+// MAX_TAG_NAME_LENGTH and MAX_TAG_VALUE_LENGTH bytes per name and value, and
+// returns an error instead of reading past the end of data.
 //
 // Learn more: https://github.com/ArweaveTeam/arweave-standards/blob/master/ans/ANS-104.md
 //
@@ -164,23 +92,35 @@ func Serialize(tags *[]Tag) ([]byte, error) {
 //	fmt.Printf("Parsed %d tags, data ends at offset %d\n", len(*tags), endOffset)
 func Deserialize(data []byte, startAt int) (*[]Tag, int, error) {
 	tags := &[]Tag{}
+	if startAt < 0 || len(data)-startAt < 16 {
+		return nil, startAt, errors.New("invalid data item - truncated tag header")
+	}
 	tagsEnd := startAt + 8 + 8
-	numberOfTags := int(binary.LittleEndian.Uint16(data[startAt : startAt+8]))
+	numberOfTags := binary.LittleEndian.Uint64(data[startAt : startAt+8])
 	numberOfTagBytesStart := startAt + 8
 	numberOfTagBytesEnd := numberOfTagBytesStart + 8
-	numberOfTagBytes := int(binary.LittleEndian.Uint16(data[numberOfTagBytesStart:numberOfTagBytesEnd]))
-	if numberOfTags > 127 {
-		return tags, tagsEnd, errors.New("invalid data item - max tags 127")
+	numberOfTagBytes := binary.LittleEndian.Uint64(data[numberOfTagBytesStart:numberOfTagBytesEnd])
+	if numberOfTags > MAX_TAGS {
+		return tags, tagsEnd, fmt.Errorf("invalid data item - max tags %d", MAX_TAGS)
 	}
-	if numberOfTags > 0 && numberOfTagBytes > 0 {
+	if numberOfTagBytes > uint64(len(data)-numberOfTagBytesEnd) {
+		return nil, tagsEnd, fmt.Errorf("invalid data item - tag byte count %d past end of data", numberOfTagBytes)
+	}
+	if (numberOfTags == 0) != (numberOfTagBytes == 0) {
+		return nil, tagsEnd, fmt.Errorf("invalid data item - %d tags in %d tag bytes", numberOfTags, numberOfTagBytes)
+	}
+	if numberOfTags > 0 {
 		bytesDataStart := numberOfTagBytesEnd
-		bytesDataEnd := numberOfTagBytesEnd + numberOfTagBytes
+		bytesDataEnd := numberOfTagBytesEnd + int(numberOfTagBytes)
 		bytesData := data[bytesDataStart:bytesDataEnd]
 
 		tags, err := fromAvro(bytesData)
 		if err != nil {
 			return nil, tagsEnd, err
 		}
+		if uint64(len(*tags)) != numberOfTags {
+			return nil, tagsEnd, fmt.Errorf("invalid data item - expected %d tags, decoded %d", numberOfTags, len(*tags))
+		}
 		tagsEnd = bytesDataEnd
 		return tags, tagsEnd, nil
 	}
@@ -210,7 +150,7 @@ func Deserialize(data []byte, startAt int) (*[]Tag, int, error) {
 //		fmt.Printf("Tag %d: %s = %s\n", i, string(tag[0]), string(tag[1]))
 //	}
 func Decode(tags *[]Tag) ([][][]byte, error) {
-	if len(*tags) == 0 {
+	if tags == nil || len(*tags) == 0 {
 		return nil, nil
 	}
 	data := make([][][]byte, 0)
@@ -248,7 +188,7 @@ func Decode(tags *[]Tag) ([][][]byte, error) {
 //	encodedTags := ConvertToBase64(&tags)
 //	// encodedTags now contains base64url-encoded names and values
 func ConvertToBase64(tags *[]Tag) *[]Tag {
-	var result []Tag
+	result := make([]Tag, 0, len(*tags))
 	for _, tag := range *tags {
 		result = append(result, Tag{Name: crypto.Base64URLEncode([]byte(tag.Name)), Value: crypto.Base64URLEncode([]byte(tag.Value))})
 	}
@@ -1,6 +1,7 @@
 package tag
 
 import (
+	"encoding/binary"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -32,3 +33,42 @@ func TestDecodeAvro(t *testing.T) {
 	assert.Equal(t, len(*expected), len(*tags))
 	assert.ElementsMatch(t, *expected, *tags)
 }
+
+// TestDeserializeMalformed verifies malformed tag headers return errors instead of panicking
+func TestDeserializeMalformed(t *testing.T) {
+	avro := []byte{2, 2, 'a', 2, 'b', 0}
+	header := func(count uint64, size uint64) []byte {
+		b := binary.LittleEndian.AppendUint64(nil, count)
+		return binary.LittleEndian.AppendUint64(b, size)
+	}
+
+	tags, end, err := Deserialize(append(header(1, uint64(len(avro))), avro...), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []Tag{{Name: "a", Value: "b"}}, *tags)
+	assert.Equal(t, 16+len(avro), end)
+
+	tests := []struct {
+		name string
+		data []byte
+		err  string
+	}{
+		{"truncated header", header(1, 6)[:15], "truncated tag header"},
+		{"tag bytes past end", append(header(1, 7), avro...), "past end"},
+		{"tag bytes overflow", append(header(1, 1<<63), avro...), "past end"},
+		{"too many tags", append(header(MAX_TAGS+1, 6), avro...), "max tags 128"},
+		{"count mismatch", append(header(2, 6), avro...), "expected 2 tags"},
+		{"tags without bytes", append(header(1, 0), avro...), "1 tags in 0 tag bytes"},
+		{"bytes without tags", append(header(0, 6), avro...), "0 tags in 6 tag bytes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := Deserialize(tt.data, 0)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+
+	_, _, err = Deserialize(header(0, 0), 17)
+	assert.Error(t, err)
+	_, _, err = Deserialize(header(0, 0), -1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,175 @@
+// Generates the reference test vectors checked by test/vectors/vectors_test.go.
+//
+//   cd test/vectors/generate && npm install && npm run generate
+//
+// Transactions are signed with test/signer.json. Their data is patternData of
+// the harness (byte i is i mod 251), so only the size is stored. Data items
+// are signed with fixed Arweave, Ethereum and ed25519 keys so the output only
+// changes when a reference implementation changes. Mnemonic keys are derived
+// from the BIP-39 test vectors with arweave-mnemonic-keys. The ANS-102 bundle
+// fixture is the oldest mainnet transaction tagged Bundle-Format: json, fetched
+// from arweave.net.
+
+import { createHash, createPrivateKey } from "node:crypto";
+import { readFileSync, writeFileSync } from "node:fs";
+import Arweave from "arweave";
+import { getKeyFromMnemonic } from "arweave-mnemonic-keys";
+import { ArweaveSigner, EthereumSigner, SolanaSigner, bundleAndSignData, createData } from "arbundles";
+import bs58 from "bs58";
+
+const KIB = 1024;
+const MAX_CHUNK_SIZE = 256 * KIB;
+const MIN_CHUNK_SIZE = 32 * KIB;
+
+const jwk = JSON.parse(readFileSync(new URL("../../signer.json", import.meta.url)));
+const arweave = Arweave.init({});
+const version = (name) => JSON.parse(readFileSync(new URL(`./node_modules/${name}/package.json`, import.meta.url))).version;
+
+const patternData = (size) => Uint8Array.from({ length: size }, (_, i) => i % 251);
+const b64url = (b) => Buffer.from(b).toString("base64url");
+
+function write(file, kind, source, vectors) {
+  writeFileSync(new URL(`../${file}`, import.meta.url), JSON.stringify({ kind, source, vectors }, null, 2) + "\n");
+  console.log(`${file}: ${vectors.length} vectors`);
+}
+
+// Sizes around the chunk boundaries: a chunk is MAX_CHUNK_SIZE unless the
+// remainder would be smaller than MIN_CHUNK_SIZE, then the last two are halved
+const sizes = [
+  0, 1, 1000,
+  MIN_CHUNK_SIZE - 1, MIN_CHUNK_SIZE, MIN_CHUNK_SIZE + 1,
+  MAX_CHUNK_SIZE - 1, MAX_CHUNK_SIZE, MAX_CHUNK_SIZE + 1,
+  MAX_CHUNK_SIZE + MIN_CHUNK_SIZE - 1, MAX_CHUNK_SIZE + MIN_CHUNK_SIZE, MAX_CHUNK_SIZE + MIN_CHUNK_SIZE + 1,
+  2 * MAX_CHUNK_SIZE - 1, 2 * MAX_CHUNK_SIZE, 2 * MAX_CHUNK_SIZE + 1,
+  2 * MAX_CHUNK_SIZE + MIN_CHUNK_SIZE - 1, 2 * MAX_CHUNK_SIZE + MIN_CHUNK_SIZE + 1,
+  3 * MAX_CHUNK_SIZE + 12345,
+];
+
+const transactions = [];
+for (const size of sizes) {
+  for (const variant of ["data", "transfer"]) {
+    if (variant === "transfer" && size !== 0 && size !== MAX_CHUNK_SIZE + 1) {
+      continue;
+    }
+    const attributes = {
+      data: patternData(size),
+      last_tx: "lqsw6xgaaunfs8h3d6n54ci1lgm2tmtqvz3wke9v9ygq64q8s68yz2jfq5xy4nec",
+      reward: "123456789",
+    };
+    if (variant === "transfer") {
+      attributes.target = "OXcT1sVRSA5eGwt2k6Yuz8-3e3g9WJi5uSE99CWqsBs";
+      attributes.quantity = "1000000000000";
+    }
+    const tx = await arweave.createTransaction(attributes, jwk);
+    tx.addTag("Content-Type", "application/octet-stream");
+    tx.addTag("Vector-Size", String(size));
+    await tx.prepareChunks(tx.data);
+    await arweave.transactions.sign(tx, jwk);
+
+    const json = tx.toJSON();
+    json.data = "";
+    transactions.push({
+      name: `${variant} ${size} bytes`,
+      data_size: size,
+      data_root: tx.data_root,
+      chunks: tx.chunks ? tx.chunks.chunks.map((c) => [c.minByteRange, c.maxByteRange]) : [],
+      proofs: tx.chunks ? tx.chunks.proofs.map((p, index) => ({ index, offset: p.offset, data_path: b64url(p.proof) })) : [],
+      transaction: json,
+      signature_data: b64url(await tx.getSignatureData()),
+    });
+  }
+}
+write("arweave-js_transactions.json", "transaction", `arweave-js ${version("arweave")}`, transactions);
+
+// Fixed keys for every signature type arbundles signs with offline
+const ethereumKey = createHash("sha256").update("goar ethereum vector key").digest("hex");
+const ed25519Seed = createHash("sha256").update("goar ed25519 vector key").digest();
+const ed25519Public = createPrivateKey({
+  key: Buffer.concat([Buffer.from("302e020100300506032b657004220420", "hex"), ed25519Seed]),
+  format: "der",
+  type: "pkcs8",
+}).export({ format: "jwk" }).x;
+const signers = {
+  arweave: new ArweaveSigner(jwk),
+  ethereum: new EthereumSigner(ethereumKey),
+  ed25519: new SolanaSigner(bs58.encode(Buffer.concat([ed25519Seed, Buffer.from(ed25519Public, "base64url")]))),
+};
+
+const items = [];
+const dataItems = [];
+for (const [name, signer] of Object.entries(signers)) {
+  const cases = [
+    { name: "empty", data: "", opts: {} },
+    { name: "tags", data: "hello", opts: { tags: [{ name: "Content-Type", value: "text/plain" }, { name: "App-Name", value: "goar" }] } },
+    {
+      name: "target and anchor",
+      data: patternData(1000),
+      opts: { target: "OXcT1sVRSA5eGwt2k6Yuz8-3e3g9WJi5uSE99CWqsBs", anchor: "thisSentenceIs32BytesLongTrustMe" },
+    },
+    { name: "long tag", data: "x", opts: { tags: [{ name: "N".repeat(1024), value: "V".repeat(3072) }] } },
+  ];
+  for (const c of cases) {
+    const item = createData(c.data, signer, c.opts);
+    await item.sign(signer);
+    items.push(item);
+    dataItems.push({
+      name: `${name} ${c.name}`,
+      raw: b64url(item.getRaw()),
+      id: item.id,
+      signature_type: item.signatureType,
+      signature: item.signature,
+      owner: item.owner,
+      target: item.target,
+      anchor: item.anchor,
+      tags: item.tags,
+      data: item.data,
+    });
+  }
+}
+write("arbundles_data_items.json", "data_item", `arbundles ${version("arbundles")}`, dataItems);
+
+const bundles = [];
+for (const [name, group] of [["single item", items.slice(0, 1)], ["every signature type", items]]) {
+  // Items that are already signed are bundled as they are
+  const b = await bundleAndSignData(group, signers.arweave);
+  bundles.push({
+    name,
+    raw: b64url(b.getRaw()),
+    items: b.items.map((item) => ({ id: item.id, size: item.getRaw().length })),
+  });
+}
+write("arbundles_bundles.json", "bundle", `arbundles ${version("arbundles")}`, bundles);
+
+const mnemonics = [
+  "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+  "legal winner thank year wave sausage worth useful legal winner thank yellow",
+  "letter advice cage absurd amount doctor acoustic avoid letter advice cage above",
+  "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+];
+const keys = [];
+for (const words of mnemonics) {
+  const key = await getKeyFromMnemonic(words);
+  keys.push({
+    name: words.split(" ").slice(0, 2).join(" "),
+    words,
+    address: await arweave.wallets.jwkToAddress(key),
+    n: key.n,
+  });
+}
+write("arweave-mnemonic-keys_keys.json", "mnemonic", `arweave-mnemonic-keys ${version("arweave-mnemonic-keys")}`, keys);
+
+// The oldest mainnet ANS-102 bundle, stored as served by the gateway
+const query = `{
+  transactions(tags: [{ name: "Bundle-Format", values: ["json"] }], sort: HEIGHT_ASC, first: 1) {
+    edges { node { id } }
+  }
+}`;
+const graphql = await fetch("https://arweave.net/graphql", {
+  method: "POST",
+  headers: { "Content-Type": "application/json" },
+  body: JSON.stringify({ query }),
+});
+const [{ node }] = (await graphql.json()).data.transactions.edges;
+const bundle = await fetch(`https://arweave.net/${node.id}`);
+writeFileSync(new URL("../../ans102-bundle.json", import.meta.url), Buffer.from(await bundle.arrayBuffer()));
+console.log(`ans102-bundle.json: transaction ${node.id}`);
@@ -0,0 +1,304 @@
+// Package vectors checks goar against test vectors produced by reference
+// implementations.
+//
+// Every *.json file in this directory holds vectors of one kind:
+//
+//	{"kind": "transaction" | "data_item" | "bundle" | "mnemonic", "source": "...", "vectors": [...]}
+//
+// The fixtures_*.json files describe the binary fixtures in test/. The
+// reference files are written by the generator in generate/: the
+// arweave-js_*.json and arbundles_*.json files cover transactions around the
+// chunk boundaries, data items of every signature type and bundles, and the
+// arweave-mnemonic-keys_*.json file holds the keys the JavaScript wallets
+// derive from mnemonics. The reference files are required: TestReferenceVectors
+// fails when one is missing, and so does every test without vectors of its
+// kind. Fields that are absent or empty in a vector are not checked.
+package vectors
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/signer"
+	"github.com/liteseed/goar/tag"
+	"github.com/liteseed/goar/transaction"
+	"github.com/liteseed/goar/transaction/bundle"
+	"github.com/liteseed/goar/transaction/data_item"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// corpus is the content of a vector file
+type corpus struct {
+	Kind    string            `json:"kind"`
+	Source  string            `json:"source"`
+	Vectors []json.RawMessage `json:"vectors"`
+}
+
+// transactionVector describes the data of a transaction and, optionally, the
+// transaction signed over it. The data is read from DataFile in test/, or is
+// patternData(DataSize).
+type transactionVector struct {
+	Name          string                   `json:"name"`
+	DataFile      string                   `json:"data_file"`
+	DataSize      int                      `json:"data_size"`
+	DataRoot      string                   `json:"data_root"`
+	Chunks        [][2]int                 `json:"chunks"` // min and max byte range of each chunk
+	Proofs        []proofVector            `json:"proofs"`
+	Transaction   *transaction.Transaction `json:"transaction"` // without data
+	SignatureData string                   `json:"signature_data"`
+}
+
+// proofVector is the Merkle proof of one chunk
+type proofVector struct {
+	Index    int    `json:"index"`
+	Offset   int    `json:"offset"`
+	DataPath string `json:"data_path"`
+}
+
+// dataItemVector is a binary data item, read from File in test/ or from Raw,
+// and its fields
+type dataItemVector struct {
+	Name          string    `json:"name"`
+	File          string    `json:"file"`
+	Raw           string    `json:"raw"`
+	ID            string    `json:"id"`
+	SignatureType int       `json:"signature_type"`
+	Signature     string    `json:"signature"`
+	Owner         string    `json:"owner"`
+	Target        string    `json:"target"`
+	Anchor        string    `json:"anchor"`
+	Tags          []tag.Tag `json:"tags"`
+	Data          string    `json:"data"`
+}
+
+// bundleVector is a binary bundle, read from File in test/ or from Raw, and
+// the ID and size of its items
+type bundleVector struct {
+	Name  string `json:"name"`
+	File  string `json:"file"`
+	Raw   string `json:"raw"`
+	Items []struct {
+		ID   string `json:"id"`
+		Size int    `json:"size"`
+	} `json:"items"`
+}
+
+// mnemonicVector is a mnemonic and the key derived from it
+type mnemonicVector struct {
+	Name    string `json:"name"`
+	Words   string `json:"words"`
+	Address string `json:"address"`
+	N       string `json:"n"` // Base64url-encoded modulus
+}
+
+// referenceFiles are the files written by the generator
+var referenceFiles = []string{
+	"arweave-js_transactions.json",
+	"arbundles_data_items.json",
+	"arbundles_bundles.json",
+	"arweave-mnemonic-keys_keys.json",
+}
+
+// patternData returns the data of generated vectors: byte i is i mod 251
+func patternData(size int) []byte {
+	b := make([]byte, size)
+	for i := range b {
+		b[i] = byte(i % 251)
+	}
+	return b
+}
+
+// load returns the vectors of a kind from every file in this directory
+func load(t *testing.T, kind string) map[string][]json.RawMessage {
+	files, err := filepath.Glob("*.json")
+	require.NoError(t, err)
+	vectors := map[string][]json.RawMessage{}
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		require.NoError(t, err)
+		c := corpus{}
+		require.NoError(t, json.Unmarshal(b, &c), file)
+		require.NotEmpty(t, c.Source, file)
+		if c.Kind == kind {
+			vectors[file] = c.Vectors
+		}
+	}
+	if len(vectors) == 0 {
+		t.Fatalf("no %s vectors, run the generator in generate/", kind)
+	}
+	return vectors
+}
+
+// readBinary returns the content of a fixture in test/ or a base64url string
+func readBinary(t *testing.T, file string, raw string) []byte {
+	if file != "" {
+		b, err := os.ReadFile(filepath.Join("..", file))
+		require.NoError(t, err)
+		return b
+	}
+	b, err := crypto.Base64URLDecode(raw)
+	require.NoError(t, err)
+	return b
+}
+
+// TestReferenceVectors verifies the reference vectors have been generated
+func TestReferenceVectors(t *testing.T) {
+	var missing []string
+	for _, file := range referenceFiles {
+		if _, err := os.Stat(file); errors.Is(err, os.ErrNotExist) {
+			missing = append(missing, file)
+		}
+	}
+	if len(missing) > 0 {
+		t.Fatalf("reference vectors not generated, run the generator in generate/: %s", strings.Join(missing, ", "))
+	}
+}
+
+// TestTransactionVectors verifies chunking, data roots, proofs and signature data
+func TestTransactionVectors(t *testing.T) {
+	for file, vectors := range load(t, "transaction") {
+		for _, raw := range vectors {
+			v := transactionVector{}
+			require.NoError(t, json.Unmarshal(raw, &v), file)
+			t.Run(file+"/"+v.Name, func(t *testing.T) {
+				var data []byte
+				if v.DataFile != "" {
+					data = readBinary(t, v.DataFile, "")
+					require.Len(t, data, v.DataSize)
+				} else {
+					data = patternData(v.DataSize)
+				}
+
+				tx := &transaction.Transaction{Format: 2}
+				require.NoError(t, tx.PrepareChunks(data))
+				assert.Equal(t, v.DataRoot, tx.DataRoot)
+
+				if v.Chunks != nil {
+					ranges := make([][2]int, len(tx.ChunkData.Chunks))
+					for i, c := range tx.ChunkData.Chunks {
+						ranges[i] = [2]int{c.MinByteRange, c.MaxByteRange}
+					}
+					assert.Equal(t, v.Chunks, ranges)
+				}
+				for _, p := range v.Proofs {
+					require.Less(t, p.Index, len(tx.ChunkData.Proofs))
+					proof := tx.ChunkData.Proofs[p.Index]
+					assert.Equal(t, p.Offset, proof.Offset, "proof %d", p.Index)
+					assert.Equal(t, p.DataPath, crypto.Base64URLEncode(proof.Proof), "proof %d", p.Index)
+				}
+
+				if v.Transaction != nil {
+					checkTransaction(t, v, data)
+				}
+			})
+		}
+	}
+}
+
+// checkTransaction verifies a transaction signed by the reference implementation
+func checkTransaction(t *testing.T, v transactionVector, data []byte) {
+	tx := v.Transaction
+	assert.Equal(t, v.DataRoot, tx.DataRoot)
+	assert.Equal(t, strconv.Itoa(len(data)), tx.DataSize)
+
+	signatureData, err := tx.SignatureData()
+	require.NoError(t, err)
+	assert.Equal(t, v.SignatureData, crypto.Base64URLEncode(signatureData))
+	assert.NoError(t, tx.Verify())
+
+	signature, err := crypto.Base64URLDecode(tx.Signature)
+	require.NoError(t, err)
+	assert.Equal(t, tx.ID, crypto.Base64URLEncode(crypto.SHA256(signature)))
+
+	// The data must give the same signature data as the header alone
+	withData := *tx
+	withData.Data = crypto.Base64URLEncode(data)
+	withData.DataRoot = ""
+	signatureData, err = withData.SignatureData()
+	require.NoError(t, err)
+	assert.Equal(t, v.SignatureData, crypto.Base64URLEncode(signatureData))
+}
+
+// TestDataItemVectors verifies every decoded field and the signature of data items
+func TestDataItemVectors(t *testing.T) {
+	for file, vectors := range load(t, "data_item") {
+		for _, raw := range vectors {
+			v := dataItemVector{}
+			require.NoError(t, json.Unmarshal(raw, &v), file)
+			t.Run(file+"/"+v.Name, func(t *testing.T) {
+				b := readBinary(t, v.File, v.Raw)
+				d, err := data_item.Decode(b)
+				require.NoError(t, err)
+
+				assert.Equal(t, v.ID, d.ID)
+				assert.Equal(t, v.SignatureType, d.SignatureType)
+				assert.Equal(t, v.Signature, d.Signature)
+				assert.Equal(t, v.Owner, d.Owner)
+				assert.Equal(t, v.Target, d.Target)
+				assert.Equal(t, v.Anchor, d.Anchor)
+				assert.Equal(t, v.Tags, *d.Tags)
+				assert.Equal(t, v.Data, d.Data)
+				assert.NoError(t, d.Verify())
+
+				streamed, err := data_item.VerifyStream(bytes.NewReader(b), int64(len(b)))
+				require.NoError(t, err)
+				assert.Equal(t, v.ID, streamed.ID)
+			})
+		}
+	}
+}
+
+// TestBundleVectors verifies the items of bundles and that goar rebuilds them byte for byte
+func TestBundleVectors(t *testing.T) {
+	for file, vectors := range load(t, "bundle") {
+		for _, raw := range vectors {
+			v := bundleVector{}
+			require.NoError(t, json.Unmarshal(raw, &v), file)
+			t.Run(file+"/"+v.Name, func(t *testing.T) {
+				b := readBinary(t, v.File, v.Raw)
+				decoded, err := bundle.Decode(b)
+				require.NoError(t, err)
+
+				require.Len(t, decoded.Items, len(v.Items))
+				for i, item := range v.Items {
+					assert.Equal(t, item.ID, decoded.Items[i].ID, "item %d", i)
+					assert.Equal(t, item.Size, len(decoded.Items[i].Raw), "item %d", i)
+				}
+				ok, err := bundle.Verify(b)
+				assert.NoError(t, err)
+				assert.True(t, ok)
+
+				rebuilt, err := bundle.New(&decoded.Items)
+				require.NoError(t, err)
+				assert.Equal(t, b, rebuilt.Raw)
+			})
+		}
+	}
+}
+
+// TestMnemonicVectors verifies mnemonics derive the keys of the JavaScript wallets
+func TestMnemonicVectors(t *testing.T) {
+	if testing.Short() {
+		t.Skip("deriving 4096-bit keys is slow")
+	}
+	for file, vectors := range load(t, "mnemonic") {
+		for _, raw := range vectors {
+			v := mnemonicVector{}
+			require.NoError(t, json.Unmarshal(raw, &v), file)
+			t.Run(file+"/"+v.Name, func(t *testing.T) {
+				s, err := signer.FromMnemonic(v.Words)
+				require.NoError(t, err)
+				assert.Equal(t, v.N, crypto.Base64URLEncode(s.PublicKey.N.Bytes()))
+				assert.Equal(t, v.Address, s.Address)
+			})
+		}
+	}
+}
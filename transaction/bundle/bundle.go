@@ -1,7 +1,15 @@
+// Package bundle creates, decodes and repacks ANS-104 bundles and reads
+// legacy ANS-102 JSON bundles.
+//
+// The package is silent and records nothing by default. Its functions have no
+// receiver to carry a Logger or Metrics field like client.Client or
+// wallet.Wallet, so SetLogger and SetRecorder configure the whole package
+// instead, and are safe to call concurrently with decoding.
 package bundle
 
 import (
-	"errors"
+	"fmt"
+	"time"
 
 	"github.com/liteseed/goar/crypto"
 	"github.com/liteseed/goar/transaction/data_item"
@@ -47,39 +55,87 @@ func New(ds *[]data_item.DataItem) (*Bundle, error) {
 }
 
 // Decode raw bytes into a Bundle
+//
+// Returns an error instead of panicking if data is truncated or malformed, or
+// if the item sizes in the header do not add up to the length of data.
 func Decode(data []byte) (*Bundle, error) {
-	// length must more than 32
-	if len(data) < 32 {
-		return nil, errors.New("binary length must more than 32")
+	start := time.Now()
+	b, err := decode(data)
+	recordDecode("binary", start, err)
+	if err != nil {
+		logger().Debug("bundle decode failed", "size", len(data), "error", err)
+	}
+	return b, err
+}
+
+func decode(data []byte) (*Bundle, error) {
+	headers, N, err := decodeBundleHeader(data)
+	if err != nil {
+		return nil, err
 	}
-	headers, N := decodeBundleHeader(data)
 	bundle := &Bundle{
-		Items: make([]data_item.DataItem, N),
-		Raw:   data,
+		Headers: headers,
+		Items:   make([]data_item.DataItem, N),
+		Raw:     data,
 	}
 	bundleStart := 32 + 64*N
 	for i := 0; i < N; i++ {
 		header := headers[i]
+		if header.Size > len(data)-bundleStart {
+			return nil, fmt.Errorf("invalid bundle - data item %d of %d bytes past end of data", i, header.Size)
+		}
 		bundleEnd := bundleStart + header.Size
 		dataItem, err := data_item.Decode(data[bundleStart:bundleEnd])
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("data item %d: %w", i, err)
 		}
 		bundle.Items[i] = *dataItem
 		bundleStart = bundleEnd
 	}
+	if bundleStart != len(data) {
+		return nil, fmt.Errorf("invalid bundle - item sizes sum to %d bytes, binary has %d", bundleStart, len(data))
+	}
 	return bundle, nil
 }
 
+// Verify checks the structure of a bundle and the signatures of its items
+//
+// The item signatures are verified in parallel across all CPUs. Returns false
+// with a nil error if the header sizes do not add up to the bundle length, and
+// false with an error if an item cannot be decoded or has an invalid signature.
 func Verify(data []byte) (bool, error) {
-	// length must more than 32
-	if len(data) < 32 {
-		return false, errors.New("binary length must more than 32")
+	headers, N, err := decodeBundleHeader(data)
+	if err != nil {
+		logger().Debug("bundle decode failed", "size", len(data), "error", err)
+		return false, err
 	}
-	headers, N := decodeBundleHeader(data)
 	dataItemSize := 0
 	for i := 0; i < N; i++ {
+		if headers[i].Size > len(data) {
+			logger().Debug("bundle verification failed", "size", len(data), "reason", "item past end of data", "item", i)
+			return false, nil
+		}
 		dataItemSize += headers[i].Size
 	}
-	return len(data) == dataItemSize+32+64*N, nil
+	if len(data) != dataItemSize+32+64*N {
+		logger().Debug("bundle verification failed", "size", len(data), "reason", "item sizes do not add up", "expected", dataItemSize+32+64*N)
+		return false, nil
+	}
+
+	b, err := Decode(data)
+	if err != nil {
+		return false, err
+	}
+	for i, d := range b.Items {
+		if d.ID != headers[i].ID {
+			err = fmt.Errorf("data item %d: id does not match the bundle header", i)
+			logger().Debug("bundle verification failed", "size", len(data), "error", err)
+			return false, err
+		}
+	}
+	if err = data_item.VerifyAll(b.Items, 0); err != nil {
+		logger().Debug("bundle verification failed", "size", len(data), "error", err)
+		return false, err
+	}
+	return true, nil
 }
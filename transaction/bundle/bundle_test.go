@@ -1,10 +1,15 @@
 package bundle
 
 import (
+	"bytes"
+	"log"
+	"log/slog"
 	"os"
 	"testing"
 
+	"github.com/liteseed/goar/metrics"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDecode(t *testing.T) {
@@ -15,5 +20,159 @@ func TestDecode(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.NotNil(t, b)
+	assert.Len(t, b.Headers, 1)
+	assert.Len(t, b.Items, 1)
+}
+
+func TestVerify(t *testing.T) {
+	data, err := os.ReadFile("../../test/signed-bundle")
+	assert.NoError(t, err)
+
+	ok, err := Verify(data)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// Truncated bundle
+	ok, err = Verify(data[:len(data)-1])
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Tampered item data
+	tampered := append([]byte{}, data...)
+	tampered[len(tampered)-1] ^= 0xff
+	ok, err = Verify(tampered)
+	assert.Error(t, err)
+	assert.False(t, ok)
+}
+
+// TestDecodeMalformed verifies truncated and malformed bundles return errors instead of panicking
+func TestDecodeMalformed(t *testing.T) {
+	data, err := os.ReadFile("../../test/signed-bundle")
+	require.NoError(t, err)
+
+	with := func(position int, values ...byte) []byte {
+		b := bytes.Clone(data)
+		copy(b[position:], values)
+		return b
+	}
+	tests := []struct {
+		name string
+		data []byte
+		err  string
+	}{
+		{"too short", data[:31], "binary length"},
+		{"header count overflow", with(0, 0xff, 0xff), "header count"},
+		{"huge header count", with(31, 1), "item count"},
+		{"huge item size", with(32+31, 1), "item 0 size"},
+		{"item past end", data[:len(data)-1], "past end"},
+		{"size sum mismatch", append(bytes.Clone(data), 0), "sum to"},
+		{"malformed item", with(32+64, 9, 0), "data item 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(tt.data)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+// FuzzDecode feeds mutated bundles to Decode, which must never panic
+func FuzzDecode(f *testing.F) {
+	data, err := os.ReadFile("../../test/signed-bundle")
+	require.NoError(f, err)
+	f.Add(data)
+	f.Add(data[:32+64])
+	item, err := os.ReadFile("../../test/1115BDataItem")
+	require.NoError(f, err)
+	header := append(longTo32ByteArray(1), longTo32ByteArray(len(item))...)
+	header = append(header, make([]byte, 32)...)
+	f.Add(append(header, item...))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		b, err := Decode(data)
+		if err != nil {
+			return
+		}
+		assert.Equal(t, len(b.Headers), len(b.Items))
+		for i, h := range b.Headers {
+			assert.Equal(t, h.Size, len(b.Items[i].Raw))
+		}
+	})
+}
+
+// TestLogger verifies decode and verification errors are logged once a logger is set
+func TestLogger(t *testing.T) {
+	data, err := os.ReadFile("../../test/signed-bundle")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { SetLogger(nil) })
+
+	_, err = Decode(data)
+	require.NoError(t, err)
+	assert.Empty(t, buf.String())
+
+	_, err = Decode(data[:31])
+	assert.Error(t, err)
+	assert.Contains(t, buf.String(), `msg="bundle decode failed" size=31`)
+
+	ok, err := Verify(data[:len(data)-1])
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Contains(t, buf.String(), `msg="bundle verification failed"`)
+
+	buf.Reset()
+	SetLogger(nil)
+	_, err = Decode(data[:31])
+	assert.Error(t, err)
+	assert.Empty(t, buf.String())
+}
+
+// TestSilentByDefault verifies decoding writes nothing to the standard logger
+func TestSilentByDefault(t *testing.T) {
+	data, err := os.ReadFile("../../test/signed-bundle")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	out := log.Writer()
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(out) })
+
+	_, err = Decode(data)
+	require.NoError(t, err)
+	_, err = Decode(data[:31])
+	assert.Error(t, err)
+	_, err = Verify(data[:len(data)-1])
+	require.NoError(t, err)
+	assert.Empty(t, buf.String())
+}
+
+// TestRecorder verifies decode time is recorded by format and result once a recorder is set
+func TestRecorder(t *testing.T) {
+	data, err := os.ReadFile("../../test/signed-bundle")
+	require.NoError(t, err)
+
+	m := metrics.NewMemory()
+	SetRecorder(m)
+	t.Cleanup(func() { SetRecorder(nil) })
+
+	_, err = Decode(data)
+	require.NoError(t, err)
+	_, err = Decode(data[:31])
+	assert.Error(t, err)
+	_, err = DecodeJSON([]byte(`{"items":[]}`))
+	require.NoError(t, err)
+
+	label := func(format string, result string) []metrics.Label {
+		return []metrics.Label{{Name: "format", Value: format}, {Name: "result", Value: result}}
+	}
+	assert.Len(t, m.Observations(metrics.BUNDLE_DECODE_DURATION, label("binary", "ok")...), 1)
+	assert.Len(t, m.Observations(metrics.BUNDLE_DECODE_DURATION, label("binary", "error")...), 1)
+	assert.Len(t, m.Observations(metrics.BUNDLE_DECODE_DURATION, label("json", "ok")...), 1)
 
+	SetRecorder(nil)
+	_, err = Decode(data)
+	require.NoError(t, err)
+	assert.Len(t, m.Observations(metrics.BUNDLE_DECODE_DURATION, label("binary", "ok")...), 1)
 }
@@ -0,0 +1,223 @@
+package bundle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/crypto/deephash"
+	"github.com/liteseed/goar/tag"
+	"github.com/liteseed/goar/transaction/data_item"
+)
+
+// Bundle-Format and Bundle-Version tag values of ANS-102 JSON bundles.
+// Learn more: https://github.com/ArweaveTeam/arweave-standards/blob/master/ans/ANS-102.md
+const (
+	JSON_FORMAT  = "json"
+	JSON_VERSION = "1.0.0"
+)
+
+// jsonBundle is the ANS-102 bundle layout.
+type jsonBundle struct {
+	Items []jsonDataItem `json:"items"`
+}
+
+// jsonDataItem is an ANS-102 data item. Every field is base64url-encoded,
+// including tag names and values.
+type jsonDataItem struct {
+	Owner     string    `json:"owner"`
+	Target    string    `json:"target"`
+	Nonce     string    `json:"nonce"`
+	Tags      []tag.Tag `json:"tags"`
+	Data      string    `json:"data"`
+	Signature string    `json:"signature"`
+	ID        string    `json:"id"`
+}
+
+// DecodeJSON decodes and verifies an ANS-102 JSON bundle.
+//
+// Each item is converted to the DataItem type returned by Decode: the nonce
+// becomes the Anchor and tags are decoded to plain strings. JSON items have
+// no binary form, so Raw is empty, and their signatures cover the ANS-102
+// deep hash layout, so DataItem.Verify (which checks the ANS-104 layout) does
+// not apply to them. DecodeJSON verifies every item instead.
+//
+// ANS-102 nonces can be any length up to 32 bytes, including empty, and the
+// decoded nonce is kept in Anchor as it is. ANS-104 anchors are empty or
+// exactly 32 bytes, so an item whose nonce has another length cannot be
+// re-encoded as a binary data item; EncodeJSON writes it back unchanged.
+//
+// Parameters:
+//   - data: The JSON bundle, e.g. the data of a transaction tagged Bundle-Format: json
+//
+// Returns the bundle, or an error if the JSON is malformed or an item has an
+// invalid field, ID or signature.
+//
+// Example:
+//
+//	b, err := bundle.DecodeJSON(data)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, d := range b.Items {
+//		fmt.Println(d.ID)
+//	}
+func DecodeJSON(data []byte) (*Bundle, error) {
+	start := time.Now()
+	b, err := decodeJSON(data)
+	recordDecode("json", start, err)
+	if err != nil {
+		logger().Debug("json bundle decode failed", "size", len(data), "error", err)
+	}
+	return b, err
+}
+
+func decodeJSON(data []byte) (*Bundle, error) {
+	j := &jsonBundle{}
+	if err := json.Unmarshal(data, j); err != nil {
+		return nil, fmt.Errorf("invalid bundle - %w", err)
+	}
+	if j.Items == nil {
+		return nil, errors.New("invalid bundle - missing items")
+	}
+
+	b := &Bundle{Items: make([]data_item.DataItem, len(j.Items)), Raw: data}
+	for i, item := range j.Items {
+		d, err := item.decode()
+		if err != nil {
+			return nil, fmt.Errorf("data item %d: %w", i, err)
+		}
+		b.Items[i] = *d
+	}
+	return b, nil
+}
+
+// EncodeJSON encodes data items signed with the ANS-102 layout, such as the
+// items returned by DecodeJSON, into a JSON bundle.
+//
+// Parameters:
+//   - items: The signed data items
+//
+// Returns the JSON bundle, or an error if an item is not signed.
+func EncodeJSON(items []data_item.DataItem) ([]byte, error) {
+	j := &jsonBundle{Items: make([]jsonDataItem, len(items))}
+	for i, d := range items {
+		if d.ID == "" || d.Signature == "" {
+			return nil, fmt.Errorf("data item %d: not signed", i)
+		}
+		tags := []tag.Tag{}
+		if d.Tags != nil {
+			tags = *tag.ConvertToBase64(d.Tags)
+		}
+		j.Items[i] = jsonDataItem{
+			Owner:     d.Owner,
+			Target:    d.Target,
+			Nonce:     crypto.Base64URLEncode([]byte(d.Anchor)),
+			Tags:      tags,
+			Data:      d.Data,
+			Signature: d.Signature,
+			ID:        d.ID,
+		}
+	}
+	return json.Marshal(j)
+}
+
+// decode converts an ANS-102 item to a DataItem and verifies it.
+//
+// Like arweave-bundles, the signature data holds the base64url strings of
+// the owner, target, nonce and tag names and values, and only the data is
+// decoded.
+func (item *jsonDataItem) decode() (*data_item.DataItem, error) {
+	rawOwner, err := decodeJSONField("owner", item.Owner)
+	if err != nil {
+		return nil, err
+	}
+	rawTarget, err := decodeJSONField("target", item.Target)
+	if err != nil {
+		return nil, err
+	}
+	if len(rawTarget) != 0 && len(rawTarget) != 32 {
+		return nil, errors.New("invalid data item - target should be 32 bytes")
+	}
+	rawNonce, err := decodeJSONField("nonce", item.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	if len(rawNonce) > 32 {
+		return nil, errors.New("invalid data item - nonce should be at most 32 bytes")
+	}
+	rawData, err := crypto.Base64URLDecode(item.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data item - data: %w", err)
+	}
+	rawSignature, err := crypto.Base64URLDecode(item.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data item - signature: %w", err)
+	}
+
+	tags := make([]tag.Tag, len(item.Tags))
+	tagChunks := make([]deephash.Chunk, len(item.Tags))
+	for i, t := range item.Tags {
+		name, err := decodeJSONField(fmt.Sprintf("tag %d name", i), t.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := decodeJSONField(fmt.Sprintf("tag %d value", i), t.Value)
+		if err != nil {
+			return nil, err
+		}
+		tags[i] = tag.Tag{Name: string(name), Value: string(value)}
+		tagChunks[i] = deephash.List(deephash.Bytes([]byte(t.Name)), deephash.Bytes([]byte(t.Value)))
+	}
+
+	if crypto.Base64URLEncode(crypto.SHA256(rawSignature)) != item.ID {
+		return nil, errors.New("invalid data item - signature and id don't match")
+	}
+	message, err := deephash.Hash(deephash.List(
+		deephash.Bytes([]byte("dataitem")),
+		deephash.Bytes([]byte("1")),
+		deephash.Bytes([]byte(item.Owner)),
+		deephash.Bytes([]byte(item.Target)),
+		deephash.Bytes([]byte(item.Nonce)),
+		deephash.List(tagChunks...),
+		deephash.Bytes(rawData),
+	))
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := crypto.NewVerifier(data_item.Arweave, rawOwner)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data item - %w", err)
+	}
+	if err = verifier.Verify(message[:], rawSignature); err != nil {
+		return nil, err
+	}
+
+	return &data_item.DataItem{
+		ID:            item.ID,
+		Signature:     item.Signature,
+		SignatureType: data_item.Arweave,
+		Owner:         item.Owner,
+		Target:        item.Target,
+		Anchor:        string(rawNonce),
+		Tags:          &tags,
+		Data:          crypto.Base64URLEncode(rawData),
+	}, nil
+}
+
+// decodeJSONField decodes a base64url field whose string is signed.
+//
+// Only the canonical encoding is accepted, so EncodeJSON gives back the
+// exact string that was signed.
+func decodeJSONField(name string, value string) ([]byte, error) {
+	b, err := crypto.Base64URLDecode(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data item - %s: %w", name, err)
+	}
+	if crypto.Base64URLEncode(b) != value {
+		return nil, fmt.Errorf("invalid data item - %s is not canonical base64url", name)
+	}
+	return b, nil
+}
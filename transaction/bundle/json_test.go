@@ -0,0 +1,161 @@
+package bundle
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/signer"
+	"github.com/liteseed/goar/tag"
+	"github.com/liteseed/goar/transaction/data_item"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signJSONItem signs an ANS-102 data item like arweave-bundles: the deep hash
+// covers the base64url strings of every field but the data
+func signJSONItem(t *testing.T, s *signer.Signer, data []byte, target string, nonce []byte, tags []tag.Tag) jsonDataItem {
+	item := jsonDataItem{
+		Owner:  s.Owner(),
+		Target: target,
+		Nonce:  crypto.Base64URLEncode(nonce),
+		Tags:   *tag.ConvertToBase64(&tags),
+		Data:   crypto.Base64URLEncode(data),
+	}
+	rawTags := make([]any, len(item.Tags))
+	for i, tg := range item.Tags {
+		rawTags[i] = [][]byte{[]byte(tg.Name), []byte(tg.Value)}
+	}
+	message := crypto.DeepHash([]any{[]byte("dataitem"), []byte("1"), []byte(item.Owner), []byte(item.Target), []byte(item.Nonce), rawTags, data})
+	signature, err := s.Sign(message[:])
+	require.NoError(t, err)
+
+	item.Signature = crypto.Base64URLEncode(signature)
+	item.ID = crypto.Base64URLEncode(crypto.SHA256(signature))
+	return item
+}
+
+// newJSONBundle returns an ANS-102 bundle of two items
+func newJSONBundle(t *testing.T) *jsonBundle {
+	s, err := signer.FromPath("../../test/signer.json")
+	require.NoError(t, err)
+	return &jsonBundle{Items: []jsonDataItem{
+		signJSONItem(t, s, []byte("first"), "", nil, []tag.Tag{}),
+		signJSONItem(t, s, []byte("second"), "OXcT1sVRSA5eGwt2k6Yuz8-3e3g9WJi5uSE99CWqsBs", []byte("nonce"), []tag.Tag{
+			{Name: "Content-Type", Value: "text/plain"},
+			{Name: "App-Name", Value: "goar"},
+		}),
+	}}
+}
+
+// TestDecodeJSON verifies ANS-102 items are verified and converted to data items
+func TestDecodeJSON(t *testing.T) {
+	j := newJSONBundle(t)
+	data, err := json.Marshal(j)
+	require.NoError(t, err)
+
+	b, err := DecodeJSON(data)
+	require.NoError(t, err)
+	require.Len(t, b.Items, 2)
+	assert.Equal(t, data, b.Raw)
+
+	d := b.Items[1]
+	assert.Equal(t, j.Items[1].ID, d.ID)
+	assert.Equal(t, "OXcT1sVRSA5eGwt2k6Yuz8-3e3g9WJi5uSE99CWqsBs", d.Target)
+	assert.Equal(t, "nonce", d.Anchor)
+	assert.Equal(t, []tag.Tag{{Name: "Content-Type", Value: "text/plain"}, {Name: "App-Name", Value: "goar"}}, *d.Tags)
+	assert.Equal(t, crypto.Base64URLEncode([]byte("second")), d.Data)
+	assert.Empty(t, d.Raw)
+
+	// Encoding the decoded items gives back the same bundle
+	encoded, err := EncodeJSON(b.Items)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(data), string(encoded))
+}
+
+// TestDecodeJSONInvalid verifies malformed and tampered bundles are rejected
+func TestDecodeJSONInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(j *jsonBundle)
+		err    string
+	}{
+		{"tampered data", func(j *jsonBundle) { j.Items[1].Data = crypto.Base64URLEncode([]byte("tampered")) }, "data item 1: crypto/rsa: verification error"},
+		{"tampered tag", func(j *jsonBundle) { j.Items[1].Tags[0].Value = crypto.Base64URLEncode([]byte("text/html")) }, "verification error"},
+		{"tampered nonce", func(j *jsonBundle) { j.Items[1].Nonce = "" }, "verification error"},
+		{"mismatched id", func(j *jsonBundle) { j.Items[0].ID = j.Items[1].ID }, "data item 0: invalid data item - signature and id don't match"},
+		{"long nonce", func(j *jsonBundle) { j.Items[0].Nonce = crypto.Base64URLEncode(make([]byte, 33)) }, "nonce should be at most 32 bytes"},
+		{"short target", func(j *jsonBundle) { j.Items[0].Target = "AAAA" }, "target should be 32 bytes"},
+		{"bad base64", func(j *jsonBundle) { j.Items[0].Owner = "!" }, "owner"},
+		{"non-canonical nonce", func(j *jsonBundle) { j.Items[1].Nonce = "bm9uY2f" }, "nonce is not canonical base64url"},
+		{"missing items", func(j *jsonBundle) { j.Items = nil }, "missing items"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := newJSONBundle(t)
+			tt.modify(j)
+			data, err := json.Marshal(j)
+			require.NoError(t, err)
+			_, err = DecodeJSON(data)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+
+	_, err := DecodeJSON([]byte("not json"))
+	assert.Error(t, err)
+
+	// An empty bundle is valid
+	b, err := DecodeJSON([]byte(`{"items":[]}`))
+	require.NoError(t, err)
+	assert.Empty(t, b.Items)
+}
+
+// TestDecodeJSONDecodedLayout verifies signatures over the decoded owner,
+// target, nonce and tags, which arweave-bundles never produces, are rejected
+func TestDecodeJSONDecodedLayout(t *testing.T) {
+	s, err := signer.FromPath("../../test/signer.json")
+	require.NoError(t, err)
+	item := signJSONItem(t, s, []byte("data"), "", []byte("nonce"), []tag.Tag{{Name: "a", Value: "b"}})
+
+	owner, err := crypto.Base64URLDecode(item.Owner)
+	require.NoError(t, err)
+	message := crypto.DeepHash([]any{[]byte("dataitem"), []byte("1"), owner, []byte{}, []byte("nonce"), []any{[][]byte{[]byte("a"), []byte("b")}}, []byte("data")})
+	signature, err := s.Sign(message[:])
+	require.NoError(t, err)
+	item.Signature = crypto.Base64URLEncode(signature)
+	item.ID = crypto.Base64URLEncode(crypto.SHA256(signature))
+
+	data, err := json.Marshal(&jsonBundle{Items: []jsonDataItem{item}})
+	require.NoError(t, err)
+	_, err = DecodeJSON(data)
+	assert.ErrorContains(t, err, "verification error")
+}
+
+// TestDecodeJSONMainnet verifies a mainnet ANS-102 bundle decodes and every
+// item is verified. The fixture is written by the generator in test/vectors/generate.
+func TestDecodeJSONMainnet(t *testing.T) {
+	data, err := os.ReadFile("../../test/ans102-bundle.json")
+	if err != nil {
+		t.Fatalf("mainnet ANS-102 bundle missing, run the generator in test/vectors/generate: %v", err)
+	}
+
+	b, err := DecodeJSON(data)
+	require.NoError(t, err)
+	require.NotEmpty(t, b.Items)
+	for _, d := range b.Items {
+		assert.Equal(t, data_item.Arweave, d.SignatureType)
+		assert.NotEmpty(t, d.ID)
+		assert.NotEmpty(t, d.Owner)
+	}
+}
+
+// TestEncodeJSONUnsigned verifies unsigned items cannot be encoded
+func TestEncodeJSONUnsigned(t *testing.T) {
+	b, err := EncodeJSON(nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"items":[]}`, string(b))
+
+	_, err = EncodeJSON(make([]data_item.DataItem, 1))
+	assert.ErrorContains(t, err, "data item 0: not signed")
+}
@@ -0,0 +1,26 @@
+package bundle
+
+import (
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/liteseed/goar/internal/logging"
+)
+
+var packageLogger atomic.Pointer[slog.Logger]
+
+// SetLogger sets the logger the package reports decode and verification
+// errors to at debug level. The package is silent until a logger is set;
+// pass nil to silence it again.
+//
+// Example:
+//
+//	bundle.SetLogger(slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})))
+func SetLogger(l *slog.Logger) {
+	packageLogger.Store(l)
+}
+
+// logger returns the package logger, or a logger that discards records.
+func logger() *slog.Logger {
+	return logging.OrDiscard(packageLogger.Load())
+}
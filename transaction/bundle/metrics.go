@@ -0,0 +1,34 @@
+package bundle
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/liteseed/goar/metrics"
+)
+
+var packageRecorder atomic.Pointer[metrics.Recorder]
+
+// SetRecorder sets the recorder the package reports decode time to, as
+// BUNDLE_DECODE_DURATION. Nothing is recorded until a recorder is set; pass
+// nil to stop recording.
+func SetRecorder(r metrics.Recorder) {
+	if r == nil {
+		packageRecorder.Store(nil)
+		return
+	}
+	packageRecorder.Store(&r)
+}
+
+// recordDecode records the time a decode took.
+func recordDecode(format string, start time.Time, err error) {
+	r := packageRecorder.Load()
+	if r == nil {
+		return
+	}
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	metrics.Since(*r, metrics.BUNDLE_DECODE_DURATION, start, metrics.Label{Name: "format", Value: format}, metrics.Label{Name: "result", Value: result})
+}
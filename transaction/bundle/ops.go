@@ -0,0 +1,223 @@
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/liteseed/goar/tag"
+	"github.com/liteseed/goar/transaction/data_item"
+)
+
+// Predicate selects data items in Filter.
+type Predicate func(d *data_item.DataItem) bool
+
+// ByTags selects data items whose tags match a query.
+//
+// Example:
+//
+//	images, err := bundle.Filter(b, bundle.ByTags(tag.MustCompile(`Content-Type ^= "image/"`)))
+func ByTags(q *tag.Query) Predicate {
+	return func(d *data_item.DataItem) bool {
+		return q.Match(d.Tags)
+	}
+}
+
+// ByOwner selects data items signed by an owner (the base64url-encoded public key).
+func ByOwner(owner string) Predicate {
+	return func(d *data_item.DataItem) bool {
+		return d.Owner == owner
+	}
+}
+
+// Filter creates a bundle of the items of b selected by keep.
+//
+// Items are copied with their binary form, so they are not re-signed and keep
+// their IDs. The order of b is preserved.
+//
+// Parameters:
+//   - b: The source bundle, e.g. returned by Decode
+//   - keep: Returns true for the items to keep
+//
+// Returns the new bundle, or an error if a kept item has no binary form (for
+// example an item of an ANS-102 JSON bundle).
+//
+// Example:
+//
+//	b, err := bundle.Filter(src, func(d *data_item.DataItem) bool {
+//		return d.Verify() == nil
+//	})
+func Filter(b *Bundle, keep Predicate) (*Bundle, error) {
+	items := []data_item.DataItem{}
+	for i := range b.Items {
+		if keep(&b.Items[i]) {
+			items = append(items, b.Items[i])
+		}
+	}
+	return repack(items)
+}
+
+// Merge creates a bundle of the items of every bundle, in order.
+//
+// An item whose ID already appeared in an earlier bundle is dropped, so
+// merging overlapping bundles does not duplicate items.
+//
+// Parameters:
+//   - bs: The bundles to merge
+//
+// Returns the merged bundle, or an error if an item has no binary form.
+//
+// Example:
+//
+//	b, err := bundle.Merge(first, second)
+func Merge(bs ...*Bundle) (*Bundle, error) {
+	items := []data_item.DataItem{}
+	seen := make(map[string]bool)
+	for _, b := range bs {
+		for _, d := range b.Items {
+			if seen[d.ID] {
+				continue
+			}
+			seen[d.ID] = true
+			items = append(items, d)
+		}
+	}
+	return repack(items)
+}
+
+// Split divides a bundle into bundles whose binary form is at most maxSize
+// bytes.
+//
+// Items stay in order and each bundle is filled before the next is started.
+// An empty bundle gives no bundles.
+//
+// Parameters:
+//   - b: The source bundle
+//   - maxSize: The largest binary size of a bundle in bytes, headers included
+//
+// Returns the bundles, or an error if maxSize is too small for an item or an
+// item has no binary form.
+//
+// Example:
+//
+//	parts, err := bundle.Split(b, 10<<20)
+func Split(b *Bundle, maxSize int) ([]*Bundle, error) {
+	var parts []*Bundle
+	var items []data_item.DataItem
+	size := 32
+	for i, d := range b.Items {
+		itemSize := 64 + len(d.Raw)
+		if 32+itemSize > maxSize {
+			return nil, fmt.Errorf("invalid bundle - data item %d of %d bytes does not fit in %d bytes", i, len(d.Raw), maxSize)
+		}
+		if size+itemSize > maxSize {
+			part, err := repack(items)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, part)
+			items, size = nil, 32
+		}
+		items = append(items, d)
+		size += itemSize
+	}
+	if len(items) > 0 {
+		part, err := repack(items)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+	return parts, nil
+}
+
+// FilterFile writes the items of the bundle file src selected by keep to dst.
+//
+// Example:
+//
+//	err := bundle.FilterFile("in.bundle", "images.bundle", bundle.ByTags(q))
+func FilterFile(src string, dst string, keep Predicate) error {
+	b, err := readFile(src)
+	if err != nil {
+		return err
+	}
+	filtered, err := Filter(b, keep)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, filtered.Raw, 0o644)
+}
+
+// MergeFiles writes the items of the bundle files srcs to dst.
+//
+// Example:
+//
+//	err := bundle.MergeFiles("merged.bundle", "a.bundle", "b.bundle")
+func MergeFiles(dst string, srcs ...string) error {
+	bs := make([]*Bundle, len(srcs))
+	for i, src := range srcs {
+		b, err := readFile(src)
+		if err != nil {
+			return err
+		}
+		bs[i] = b
+	}
+	merged, err := Merge(bs...)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, merged.Raw, 0o644)
+}
+
+// SplitFile splits the bundle file src into files of at most maxSize bytes in
+// the directory dir, named after src with a part number (in.bundle gives
+// in.0.bundle, in.1.bundle, ...).
+//
+// Returns the paths of the files written, in order.
+//
+// Example:
+//
+//	paths, err := bundle.SplitFile("in.bundle", "out", 10<<20)
+func SplitFile(src string, dir string, maxSize int) ([]string, error) {
+	b, err := readFile(src)
+	if err != nil {
+		return nil, err
+	}
+	parts, err := Split(b, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	base := filepath.Base(src)
+	ext := filepath.Ext(base)
+	paths := make([]string, len(parts))
+	for i, part := range parts {
+		paths[i] = filepath.Join(dir, fmt.Sprintf("%s.%d%s", base[:len(base)-len(ext)], i, ext))
+		if err = os.WriteFile(paths[i], part.Raw, 0o644); err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
+
+// repack builds a bundle from the binary form of items.
+func repack(items []data_item.DataItem) (*Bundle, error) {
+	for i, d := range items {
+		if len(d.Raw) == 0 {
+			return nil, fmt.Errorf("data item %d has no binary form to repack", i)
+		}
+	}
+	return New(&items)
+}
+
+// readFile reads and decodes a bundle file.
+func readFile(path string) (*Bundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	b, err := Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return b, nil
+}
@@ -0,0 +1,146 @@
+package bundle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/liteseed/goar/signer"
+	"github.com/liteseed/goar/tag"
+	"github.com/liteseed/goar/transaction/data_item"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestBundle returns a decoded bundle of signed items, one per content type
+func newTestBundle(t *testing.T, contentTypes ...string) *Bundle {
+	s, err := signer.FromPath("../../test/signer.json")
+	require.NoError(t, err)
+	items := make([]data_item.DataItem, len(contentTypes))
+	for i, contentType := range contentTypes {
+		d := data_item.New([]byte(contentType), "", "", &[]tag.Tag{tag.ContentType(contentType)})
+		require.NoError(t, d.Sign(s))
+		items[i] = *d
+	}
+	b, err := New(&items)
+	require.NoError(t, err)
+	decoded, err := Decode(b.Raw)
+	require.NoError(t, err)
+	return decoded
+}
+
+// ids returns the IDs of the items of a bundle
+func ids(b *Bundle) []string {
+	ids := make([]string, len(b.Items))
+	for i, d := range b.Items {
+		ids[i] = d.ID
+	}
+	return ids
+}
+
+// TestFilter verifies filtered bundles keep the selected items unchanged
+func TestFilter(t *testing.T) {
+	b := newTestBundle(t, "image/png", "text/plain", "image/jpeg")
+
+	images, err := Filter(b, ByTags(tag.MustCompile(`Content-Type ^= "image/"`)))
+	require.NoError(t, err)
+	assert.Equal(t, []string{b.Items[0].ID, b.Items[2].ID}, ids(images))
+	ok, err := Verify(images.Raw)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	decoded, err := Decode(images.Raw)
+	require.NoError(t, err)
+	assert.Equal(t, b.Items[2].Raw, decoded.Items[1].Raw)
+
+	all, err := Filter(b, ByOwner(b.Items[0].Owner))
+	require.NoError(t, err)
+	assert.Equal(t, b.Raw, all.Raw)
+
+	none, err := Filter(b, ByOwner("other"))
+	require.NoError(t, err)
+	assert.Empty(t, none.Items)
+	assert.Len(t, none.Raw, 32)
+}
+
+// TestMerge verifies merged bundles contain every item once, in order
+func TestMerge(t *testing.T) {
+	first := newTestBundle(t, "text/plain", "image/png")
+	second := newTestBundle(t, "text/html")
+	overlap, err := Filter(first, func(d *data_item.DataItem) bool { return d.ID == first.Items[1].ID })
+	require.NoError(t, err)
+
+	merged, err := Merge(first, second, overlap)
+	require.NoError(t, err)
+	assert.Equal(t, append(ids(first), ids(second)...), ids(merged))
+	ok, err := Verify(merged.Raw)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+// TestSplit verifies split bundles respect the size limit and keep the item order
+func TestSplit(t *testing.T) {
+	b := newTestBundle(t, "a/a", "b/b", "c/c", "d/d", "e/e")
+	itemSize := 64 + len(b.Items[0].Raw)
+
+	parts, err := Split(b, 32+2*itemSize)
+	require.NoError(t, err)
+	require.Len(t, parts, 3)
+	var split []string
+	for _, part := range parts {
+		assert.LessOrEqual(t, len(part.Raw), 32+2*itemSize)
+		ok, err := Verify(part.Raw)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		split = append(split, ids(part)...)
+	}
+	assert.Equal(t, ids(b), split)
+
+	// A single part when everything fits
+	parts, err = Split(b, len(b.Raw))
+	require.NoError(t, err)
+	require.Len(t, parts, 1)
+	assert.Equal(t, b.Raw, parts[0].Raw)
+
+	_, err = Split(b, 32+itemSize-1)
+	assert.ErrorContains(t, err, "does not fit")
+
+	parts, err = Split(&Bundle{}, 1024)
+	require.NoError(t, err)
+	assert.Empty(t, parts)
+}
+
+// TestRepackJSONItems verifies items without a binary form cannot be repacked
+func TestRepackJSONItems(t *testing.T) {
+	_, err := Merge(&Bundle{Items: make([]data_item.DataItem, 1)})
+	assert.ErrorContains(t, err, "no binary form")
+}
+
+// TestFiles verifies the file wrappers
+func TestFiles(t *testing.T) {
+	dir := t.TempDir()
+	b := newTestBundle(t, "image/png", "text/html", "image/gif")
+	src := filepath.Join(dir, "in.bundle")
+	require.NoError(t, os.WriteFile(src, b.Raw, 0o644))
+
+	dst := filepath.Join(dir, "images.bundle")
+	require.NoError(t, FilterFile(src, dst, ByTags(tag.MustCompile(`Content-Type ^= "image/"`))))
+	images, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	filtered, err := Decode(images)
+	require.NoError(t, err)
+	assert.Len(t, filtered.Items, 2)
+
+	paths, err := SplitFile(src, dir, 32+64+len(b.Items[0].Raw))
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "in.0.bundle"), filepath.Join(dir, "in.1.bundle"), filepath.Join(dir, "in.2.bundle")}, paths)
+
+	merged := filepath.Join(dir, "merged.bundle")
+	require.NoError(t, MergeFiles(merged, paths...))
+	data, err := os.ReadFile(merged)
+	require.NoError(t, err)
+	assert.Equal(t, b.Raw, data)
+
+	err = MergeFiles(merged, filepath.Join(dir, "missing.bundle"))
+	assert.Error(t, err)
+}
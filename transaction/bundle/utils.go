@@ -1,7 +1,8 @@
 package bundle
 
 import (
-	"log"
+	"errors"
+	"fmt"
 
 	"github.com/liteseed/goar/crypto"
 	"github.com/liteseed/goar/transaction/data_item"
@@ -23,17 +24,42 @@ func generateBundleHeader(d *[]data_item.DataItem) (*[]Header, error) {
 	return &headers, nil
 }
 
-func decodeBundleHeader(data []byte) ([]Header, int) {
-	N := byteArrayToLong(data[:32])
-	var headers []Header
+// decodeBundleHeader decodes the item count and the size and ID of every item.
+//
+// Returns an error if the count does not fit in data or a size does not fit in
+// an int.
+func decodeBundleHeader(data []byte) ([]Header, int, error) {
+	if len(data) < 32 {
+		return nil, 0, errors.New("binary length must more than 32")
+	}
+	N, err := decodeSize(data[:32])
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid bundle - item count: %w", err)
+	}
+	if N > (len(data)-32)/64 {
+		return nil, 0, fmt.Errorf("invalid bundle - header count %d overflows a %d byte binary", N, len(data))
+	}
+	headers := make([]Header, 0, N)
 	for i := 32; i < 32+64*N; i += 64 {
-		log.Println(i, i+32, i+32, i+64)
-		log.Println(len(data[i:i+32]), len(data[i+32:i+64]))
-		size := byteArrayToLong(data[i : i+32])
+		size, err := decodeSize(data[i : i+32])
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid bundle - item %d size: %w", len(headers), err)
+		}
 		id := crypto.Base64URLEncode(data[i+32 : i+64])
 		headers = append(headers, Header{ID: id, Size: size, Raw: data[i : i+64]})
 	}
-	return headers, N
+	return headers, N, nil
+}
+
+// decodeSize decodes a 32-byte little-endian size, rejecting values above
+// 2^56 so the result cannot overflow an int.
+func decodeSize(b []byte) (int, error) {
+	for _, v := range b[7:] {
+		if v != 0 {
+			return 0, errors.New("value too large")
+		}
+	}
+	return byteArrayToLong(b), nil
 }
 
 func longTo32ByteArray(long int) []byte {
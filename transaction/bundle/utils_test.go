@@ -14,7 +14,8 @@ func TestDecodeBundleHeader(t *testing.T) {
 	if err != nil {
 		log.Fatal(err)
 	}
-	headers, N := decodeBundleHeader(data)
+	headers, N, err := decodeBundleHeader(data)
+	assert.NoError(t, err)
 	assert.Equal(t, N, 1)
 	assert.Equal(t, 1063, headers[0].Size)
 	assert.Equal(t, "Rh71hbi1SjdweiLSgJQioZ4VLlsnN0PM1Zzkzo_S3w0", headers[0].ID)
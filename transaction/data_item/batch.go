@@ -0,0 +1,63 @@
+package data_item
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// VerifyAll verifies many data items in parallel.
+//
+// The deep hash and signature check of each item are independent, so the
+// items are spread across parallelism goroutines. This is much faster than
+// calling Verify in a loop for bundles with many items.
+//
+// Parameters:
+//   - items: The data items to verify
+//   - parallelism: Number of goroutines (values below 1 use the number of CPUs)
+//
+// Returns nil if every item is valid, or the error of the invalid item with
+// the lowest index.
+//
+// Example:
+//
+//	b, err := bundle.Decode(raw)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	if err = data_item.VerifyAll(b.Items, 0); err != nil {
+//		log.Fatal(err)
+//	}
+func VerifyAll(items []DataItem, parallelism int) error {
+	if parallelism < 1 {
+		parallelism = runtime.NumCPU()
+	}
+	if parallelism > len(items) {
+		parallelism = len(items)
+	}
+
+	errs := make([]error, len(items))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < parallelism; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				errs[i] = items[i].Verify()
+			}
+		}()
+	}
+	for i := range items {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("data item %d (%s): %w", i, items[i].ID, err)
+		}
+	}
+	return nil
+}
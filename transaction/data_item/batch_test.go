@@ -0,0 +1,105 @@
+package data_item
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/signer"
+	"github.com/liteseed/goar/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+)
+
+// encodeRaw builds the binary of a data item without target or anchor
+func encodeRaw(t *testing.T, d *DataItem, signature []byte) []byte {
+	owner, err := crypto.Base64URLDecode(d.Owner)
+	require.NoError(t, err)
+	tags, err := tag.Serialize(d.Tags)
+	require.NoError(t, err)
+	data, err := crypto.Base64URLDecode(d.Data)
+	require.NoError(t, err)
+
+	raw := binary.LittleEndian.AppendUint16(nil, uint16(d.SignatureType))
+	raw = append(raw, signature...)
+	raw = append(raw, owner...)
+	raw = append(raw, 0, 0)
+	raw = binary.LittleEndian.AppendUint64(raw, uint64(len(*d.Tags)))
+	raw = binary.LittleEndian.AppendUint64(raw, uint64(len(tags)))
+	raw = append(raw, tags...)
+	return append(raw, data...)
+}
+
+// TestVerifyED25519 verifies data items signed with ED25519 keys
+func TestVerifyED25519(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	d := New([]byte("hello"), "", "", &[]tag.Tag{{Name: "App-Name", Value: "goar"}})
+	d.SignatureType = ED25519
+	d.Owner = crypto.Base64URLEncode(publicKey)
+	message, err := d.getDataItemChunk()
+	require.NoError(t, err)
+
+	decoded, err := Decode(encodeRaw(t, d, ed25519.Sign(privateKey, message)))
+	require.NoError(t, err)
+	assert.Equal(t, ED25519, decoded.SignatureType)
+	assert.NoError(t, decoded.Verify())
+
+	// The signature type is part of the signed message
+	d.SignatureType = Arweave
+	wrongType, err := d.getDataItemChunk()
+	require.NoError(t, err)
+	d.SignatureType = ED25519
+	decoded, err = Decode(encodeRaw(t, d, ed25519.Sign(privateKey, wrongType)))
+	require.NoError(t, err)
+	assert.Error(t, decoded.Verify())
+}
+
+// TestVerifyEthereum verifies data items signed by Ethereum wallets
+func TestVerifyEthereum(t *testing.T) {
+	key, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	d := New([]byte("hello"), "", "", nil)
+	d.SignatureType = Ethereum
+	d.Owner = crypto.Base64URLEncode(key.PubKey().SerializeUncompressed())
+	message, err := d.getDataItemChunk()
+	require.NoError(t, err)
+
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte("\x19Ethereum Signed Message:\n48"))
+	h.Write(message)
+	compact := ecdsa.SignCompact(key, h.Sum(nil), false)
+	signature := append(append([]byte{}, compact[1:]...), compact[0]-27)
+
+	decoded, err := Decode(encodeRaw(t, d, signature))
+	require.NoError(t, err)
+	assert.NoError(t, decoded.Verify())
+}
+
+// TestVerifyAll verifies batches report the first invalid item
+func TestVerifyAll(t *testing.T) {
+	s, err := signer.FromPath("../../test/signer.json")
+	require.NoError(t, err)
+
+	items := make([]DataItem, 20)
+	for i := range items {
+		d := New([]byte{byte(i)}, "", "", nil)
+		require.NoError(t, d.Sign(s))
+		items[i] = *d
+	}
+	assert.NoError(t, VerifyAll(items, 0))
+	assert.NoError(t, VerifyAll(items, 3))
+	assert.NoError(t, VerifyAll(nil, 0))
+
+	items[7].Data = crypto.Base64URLEncode([]byte("tampered"))
+	items[12].Data = crypto.Base64URLEncode([]byte("tampered"))
+	err = VerifyAll(items, 4)
+	assert.ErrorContains(t, err, "data item 7")
+}
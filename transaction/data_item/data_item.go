@@ -1,18 +1,19 @@
 package data_item
 
 import (
-	"encoding/binary"
 	"errors"
+	"fmt"
 
 	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/crypto/deephash"
 	"github.com/liteseed/goar/signer"
 	"github.com/liteseed/goar/tag"
 )
 
 const (
-	MAX_TAGS             = 128
-	MAX_TAG_KEY_LENGTH   = 1024
-	MAX_TAG_VALUE_LENGTH = 3072
+	MAX_TAGS             = tag.MAX_TAGS
+	MAX_TAG_KEY_LENGTH   = tag.MAX_TAG_NAME_LENGTH
+	MAX_TAG_VALUE_LENGTH = tag.MAX_TAG_VALUE_LENGTH
 )
 
 // New Create a new DataItem
@@ -30,6 +31,9 @@ func New(rawData []byte, target string, anchor string, tags *[]tag.Tag) *DataIte
 }
 
 // Decode a [DataItem] from bytes
+//
+// Returns an error instead of panicking if raw is truncated or malformed, so
+// it is safe to use on untrusted input.
 func Decode(raw []byte) (*DataItem, error) {
 	N := len(raw)
 	if N < 2 {
@@ -43,17 +47,29 @@ func Decode(raw []byte) (*DataItem, error) {
 
 	signatureStart := 2
 	signatureEnd := signatureLength + signatureStart
+	if N < signatureEnd {
+		return nil, fmt.Errorf("invalid data item - truncated signature: need %d bytes, got %d", signatureEnd, N)
+	}
 
 	signature := crypto.Base64URLEncode(raw[signatureStart:signatureEnd])
 	rawId := crypto.SHA256(raw[signatureStart:signatureEnd])
 	id := crypto.Base64URLEncode(rawId)
 	ownerStart := signatureEnd
 	ownerEnd := ownerStart + publicKeyLength
+	if N < ownerEnd {
+		return nil, fmt.Errorf("invalid data item - truncated owner: need %d bytes, got %d", ownerEnd, N)
+	}
 	owner := crypto.Base64URLEncode(raw[ownerStart:ownerEnd])
 
 	position := ownerEnd
-	target, position := getTarget(&raw, position)
-	anchor, position := getAnchor(&raw, position)
+	target, position, err := getTarget(&raw, position)
+	if err != nil {
+		return nil, err
+	}
+	anchor, position, err := getAnchor(&raw, position)
+	if err != nil {
+		return nil, err
+	}
 	tags, position, err := tag.Deserialize(raw, position)
 	if err != nil {
 		return nil, err
@@ -73,14 +89,18 @@ func Decode(raw []byte) (*DataItem, error) {
 	}, nil
 }
 
-func (d *DataItem) Sign(s *signer.Signer) error {
+func (d *DataItem) Sign(s signer.Interface) error {
+	if err := tag.Validate(d.Tags); err != nil {
+		return fmt.Errorf("invalid data item - %w", err)
+	}
 	d.Owner = s.Owner()
+	d.SignatureType = Arweave
 	deepHashChunk, err := d.getDataItemChunk()
 	if err != nil {
 		return err
 	}
 
-	rawSignature, err := crypto.Sign(deepHashChunk, s.PrivateKey)
+	rawSignature, err := s.Sign(deepHashChunk)
 	if err != nil {
 		return err
 	}
@@ -90,13 +110,7 @@ func (d *DataItem) Sign(s *signer.Signer) error {
 		return err
 	}
 
-	rawTarget, err := crypto.Base64URLDecode(d.Target)
-	if err != nil {
-		return err
-	}
-	rawAnchor := []byte(d.Anchor)
-
-	rawTags, err := tag.Serialize(d.Tags)
+	raw, err := encodeHeader(Arweave, rawSignature, rawOwner, d.Target, d.Anchor, d.Tags)
 	if err != nil {
 		return err
 	}
@@ -104,33 +118,6 @@ func (d *DataItem) Sign(s *signer.Signer) error {
 	if err != nil {
 		return err
 	}
-
-	raw := make([]byte, 0)
-	raw = binary.LittleEndian.AppendUint16(raw, uint16(1))
-	raw = append(raw, rawSignature...)
-	raw = append(raw, rawOwner...)
-
-	if d.Target == "" {
-		raw = append(raw, 0)
-	} else {
-		raw = append(raw, 1)
-	}
-	raw = append(raw, rawTarget...)
-
-	if d.Anchor == "" {
-		raw = append(raw, 0)
-	} else {
-		raw = append(raw, 1)
-	}
-	raw = append(raw, rawAnchor...)
-	numberOfTags := make([]byte, 8)
-	binary.LittleEndian.PutUint16(numberOfTags, uint16(len(*d.Tags)))
-	raw = append(raw, numberOfTags...)
-
-	tagsLength := make([]byte, 8)
-	binary.LittleEndian.PutUint16(tagsLength, uint16(len(rawTags)))
-	raw = append(raw, tagsLength...)
-	raw = append(raw, rawTags...)
 	raw = append(raw, rawData...)
 	rawID := crypto.SHA256(rawSignature)
 
@@ -141,7 +128,31 @@ func (d *DataItem) Sign(s *signer.Signer) error {
 	return nil
 }
 
+// MatchTags reports whether the tags of the data item satisfy a query.
+//
+// Data item tags are plain strings, so this is q.Match(d.Tags); use it
+// instead of choosing between Match and MatchBase64.
+//
+// Example:
+//
+//	if d.MatchTags(tag.MustCompile(`Content-Type ^= "image/"`)) {
+//		fmt.Println(d.ID)
+//	}
+func (d *DataItem) MatchTags(q *tag.Query) bool {
+	return q.Match(d.Tags)
+}
+
 func (d *DataItem) Verify() error {
+	rawData, err := crypto.Base64URLDecode(d.Data)
+	if err != nil {
+		return err
+	}
+	return d.verify(deephash.Bytes(rawData))
+}
+
+// verify checks the ID, signature, tags and anchor of a data item with the
+// given payload chunk.
+func (d *DataItem) verify(data deephash.Chunk) error {
 	// Verify ID
 	rawSignature, err := crypto.Base64URLDecode(d.Signature)
 	if err != nil {
@@ -155,32 +166,31 @@ func (d *DataItem) Verify() error {
 		return errors.New("invalid data item - signature and id don't match")
 	}
 
-	chunks, err := d.getDataItemChunk()
+	chunks, err := d.hashDataItem(data)
 	if err != nil {
 		return err
 	}
 
-	publicKey, err := crypto.GetPublicKeyFromOwner(d.Owner)
+	signatureType := d.SignatureType
+	if signatureType == 0 {
+		signatureType = Arweave
+	}
+	rawOwner, err := crypto.Base64URLDecode(d.Owner)
 	if err != nil {
 		return err
 	}
-	err = crypto.Verify(chunks, rawSignature, publicKey)
+	verifier, err := crypto.NewVerifier(signatureType, rawOwner)
+	if err != nil {
+		return fmt.Errorf("invalid data item - %w", err)
+	}
+	err = verifier.Verify(chunks, rawSignature)
 	if err != nil {
 		return err
 	}
 
 	// VERIFY TAGS
-	if len(*d.Tags) > MAX_TAGS {
-		return errors.New("invalid data item - tags cannot be more than 128")
-	}
-
-	for _, t := range *d.Tags {
-		if len([]byte(t.Name)) == 0 || len([]byte(t.Name)) > MAX_TAG_KEY_LENGTH {
-			return errors.New("invalid data item - tag key too long")
-		}
-		if len([]byte(t.Value)) == 0 || len([]byte(t.Value)) > MAX_TAG_VALUE_LENGTH {
-			return errors.New("invalid data item - tag value too long")
-		}
+	if err = tag.Validate(d.Tags); err != nil {
+		return fmt.Errorf("invalid data item - %w", err)
 	}
 
 	if len([]byte(d.Anchor)) > 32 {
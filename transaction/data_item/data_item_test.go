@@ -1,13 +1,17 @@
 package data_item
 
 import (
+	"bytes"
 	"encoding/base64"
+	"encoding/binary"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/liteseed/goar/signer"
 	"github.com/liteseed/goar/tag"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDecode(t *testing.T) {
@@ -176,3 +180,114 @@ func TestVerifyDataItem(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestSignInvalidTags(t *testing.T) {
+	s, err := signer.FromPath("../../test/signer.json")
+	assert.NoError(t, err)
+
+	dataItem := New([]byte("data"), "", "", &[]tag.Tag{{Name: "Content-Type", Value: ""}})
+	err = dataItem.Sign(s)
+	assert.Error(t, err)
+	assert.Empty(t, dataItem.ID)
+}
+
+// TestDecodeMalformed verifies truncated and malformed binaries return errors instead of panicking
+func TestDecodeMalformed(t *testing.T) {
+	raw, err := os.ReadFile("../../test/1115BDataItem")
+	require.NoError(t, err)
+	const presence = 2 + 512 + 512
+
+	with := func(position int, values ...byte) []byte {
+		b := bytes.Clone(raw)
+		copy(b[position:], values)
+		return b
+	}
+	tests := []struct {
+		name string
+		data []byte
+		err  string
+	}{
+		{"empty", nil, "binary too small"},
+		{"unsupported signature type", with(0, 9, 0), "unsupported signature type"},
+		{"truncated signature", raw[:300], "truncated signature"},
+		{"truncated owner", raw[:800], "truncated owner"},
+		{"missing target presence byte", raw[:presence], "truncated target presence byte"},
+		{"bad target presence byte", with(presence, 2), "invalid target presence byte 2"},
+		{"truncated target", with(presence, 1)[:presence+20], "truncated target"},
+		{"bad anchor presence byte", with(presence+1, 7), "invalid anchor presence byte 7"},
+		{"truncated tag header", raw[:presence+10], "truncated tag header"},
+		{"tag bytes past end", with(presence+2+8, 0xff, 0xff), "past end"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(tt.data)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+// FuzzDecode feeds mutated data items to Decode, which must never panic
+func FuzzDecode(f *testing.F) {
+	raw, err := os.ReadFile("../../test/1115BDataItem")
+	require.NoError(f, err)
+	f.Add(raw)
+	f.Add(raw[:1100])
+	f.Add(raw[:1030])
+	s, err := signer.FromPath("../../test/signer.json")
+	require.NoError(f, err)
+	d := New([]byte("data"), "OXcT1sVRSA5eGwt2k6Yuz8-3e3g9WJi5uSE99CWqsBs", "thisSentenceIs32BytesLongTrustMe", &[]tag.Tag{{Name: "a", Value: "b"}})
+	require.NoError(f, d.Sign(s))
+	f.Add(d.Raw)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		d, err := Decode(data)
+		if err != nil {
+			return
+		}
+		assert.Equal(t, data, d.Raw)
+		// The streaming decoder must accept the same headers
+		_, _, err = decodeHeader(bytes.NewReader(data), int64(len(data)))
+		assert.NoError(t, err)
+	})
+}
+
+// TestTagsConformance verifies re-encoding the tags of an arweave-js data item gives the same bytes
+func TestTagsConformance(t *testing.T) {
+	raw, err := os.ReadFile("../../test/1115BDataItem")
+	require.NoError(t, err)
+	d, err := Decode(raw)
+	require.NoError(t, err)
+
+	rawTags, err := tag.Serialize(d.Tags)
+	require.NoError(t, err)
+	const tagsStart = 2 + 512 + 512 + 1 + 1 + 16
+	assert.Equal(t, raw[tagsStart:tagsStart+len(rawTags)], rawTags)
+	assert.Equal(t, uint64(len(rawTags)), binary.LittleEndian.Uint64(raw[tagsStart-8:tagsStart]))
+}
+
+// TestLargeTags verifies tags encoding to more than 65535 bytes round-trip
+func TestLargeTags(t *testing.T) {
+	s, err := signer.FromPath("../../test/signer.json")
+	require.NoError(t, err)
+
+	tags := make([]tag.Tag, tag.MAX_TAGS)
+	for i := range tags {
+		tags[i] = tag.Tag{Name: strings.Repeat("n", tag.MAX_TAG_NAME_LENGTH), Value: strings.Repeat("v", tag.MAX_TAG_VALUE_LENGTH)}
+	}
+	d := New([]byte("data"), "", "", &tags)
+	require.NoError(t, d.Sign(s))
+
+	decoded, err := Decode(d.Raw)
+	require.NoError(t, err)
+	assert.Equal(t, tags, *decoded.Tags)
+	assert.Equal(t, d.Data, decoded.Data)
+	assert.NoError(t, decoded.Verify())
+}
+
+// TestMatchTags verifies data items are matched on their plain tags
+func TestMatchTags(t *testing.T) {
+	d := New([]byte("image"), "", "", &[]tag.Tag{{Name: "Content-Type", Value: "image/png"}})
+	assert.True(t, d.MatchTags(tag.MustCompile(`Content-Type ^= "image/"`)))
+	assert.False(t, d.MatchTags(tag.MustCompile(`HAS App-Name`)))
+	assert.True(t, New(nil, "", "", nil).MatchTags(tag.MustCompile(`NOT HAS Type`)))
+}
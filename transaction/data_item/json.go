@@ -0,0 +1,222 @@
+package data_item
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/tag"
+)
+
+// dataItemJSON is the JSON form of a signed data item, in the transaction
+// layout gateways serve at /tx/{id}: binary fields, including the anchor
+// (last_tx) and the tag names and values, are base64url-encoded. Data items
+// carry no quantity, reward or data root, so those are always "0" or empty.
+// The signature type is only written for items not signed with an Arweave
+// key, which the transaction layout cannot describe.
+type dataItemJSON struct {
+	Format        int       `json:"format"`
+	ID            string    `json:"id"`
+	LastTx        string    `json:"last_tx"`
+	Owner         string    `json:"owner"`
+	Tags          []tag.Tag `json:"tags"`
+	Target        string    `json:"target"`
+	Quantity      string    `json:"quantity"`
+	Data          string    `json:"data"`
+	DataSize      string    `json:"data_size"`
+	DataRoot      string    `json:"data_root"`
+	Reward        string    `json:"reward"`
+	Signature     string    `json:"signature"`
+	SignatureType int       `json:"signature_type,omitempty"`
+}
+
+// JSON_FORMAT is the format field of the JSON form of data items.
+const JSON_FORMAT = 2
+
+// ToJSON returns the JSON form of a signed data item, in the transaction
+// layout gateways serve at /tx/{id}.
+//
+// The output is deterministic: fields are always written in the same order,
+// tags keep their binary order and there is no insignificant whitespace, so
+// the same data item always gives the same bytes.
+//
+// Returns the JSON, or an error if the data item is not signed.
+//
+// Example:
+//
+//	b, err := d.ToJSON()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	// {"format":2,"id":"...","last_tx":"...","owner":"...","tags":[...],...}
+func (d *DataItem) ToJSON() ([]byte, error) {
+	if d.ID == "" || d.Signature == "" {
+		return nil, errors.New("invalid data item - not signed")
+	}
+	signatureType := d.SignatureType
+	if signatureType == Arweave {
+		signatureType = 0
+	}
+	tags := []tag.Tag{}
+	if d.Tags != nil {
+		tags = *tag.ConvertToBase64(d.Tags)
+	}
+	return json.Marshal(&dataItemJSON{
+		Format:        JSON_FORMAT,
+		ID:            d.ID,
+		LastTx:        crypto.Base64URLEncode([]byte(d.Anchor)),
+		Owner:         d.Owner,
+		Tags:          tags,
+		Target:        d.Target,
+		Quantity:      "0",
+		Data:          d.Data,
+		DataSize:      strconv.Itoa(base64.RawURLEncoding.DecodedLen(len(d.Data))),
+		Reward:        "0",
+		Signature:     d.Signature,
+		SignatureType: signatureType,
+	})
+}
+
+// FromJSON rebuilds a signed data item from its JSON form.
+//
+// The binary form is re-serialized from the JSON fields and decoded again,
+// then the ID and signature are verified, so the returned data item (including
+// Raw) is exactly the one that was signed. The signature type defaults to
+// Arweave when absent, and data_size, when present, must match the data.
+//
+// Parameters:
+//   - b: The JSON produced by ToJSON or served by a gateway
+//
+// Returns the data item, or an error if a field is malformed, the item
+// transfers AR or pays a reward, the ID does not match the signature, or the
+// signature is invalid.
+//
+// Example:
+//
+//	d, err := data_item.FromJSON(body)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	os.WriteFile(d.ID, d.Raw, 0o644)
+func FromJSON(b []byte) (*DataItem, error) {
+	j := &dataItemJSON{}
+	if err := json.Unmarshal(b, j); err != nil {
+		return nil, fmt.Errorf("invalid data item - %w", err)
+	}
+	if j.Format != 0 && j.Format != JSON_FORMAT {
+		return nil, fmt.Errorf("invalid data item - unsupported format: %d", j.Format)
+	}
+	if !isZeroAmount(j.Quantity) || !isZeroAmount(j.Reward) {
+		return nil, errors.New("invalid data item - data items have no quantity or reward")
+	}
+	if j.SignatureType == 0 {
+		j.SignatureType = Arweave
+	}
+	meta, ok := SignatureConfig[j.SignatureType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported signature type:%d", j.SignatureType)
+	}
+
+	rawSignature, err := decodeField("signature", j.Signature, meta.SignatureLength)
+	if err != nil {
+		return nil, err
+	}
+	rawOwner, err := decodeField("owner", j.Owner, meta.PublicKeyLength)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = decodeOptionalField("target", j.Target); err != nil {
+		return nil, err
+	}
+	rawAnchor, err := decodeOptionalField("last_tx", j.LastTx)
+	if err != nil {
+		return nil, err
+	}
+	rawData, err := crypto.Base64URLDecode(j.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data item - data: %w", err)
+	}
+	if j.DataSize != "" && j.DataSize != strconv.Itoa(len(rawData)) {
+		return nil, fmt.Errorf("invalid data item - data_size %s does not match %d bytes of data", j.DataSize, len(rawData))
+	}
+	tags := make([]tag.Tag, len(j.Tags))
+	for i, t := range j.Tags {
+		name, err := crypto.Base64URLDecode(t.Name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid data item - tag %d name: %w", i, err)
+		}
+		value, err := crypto.Base64URLDecode(t.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid data item - tag %d value: %w", i, err)
+		}
+		tags[i] = tag.Tag{Name: string(name), Value: string(value)}
+	}
+	if err = tag.Validate(&tags); err != nil {
+		return nil, fmt.Errorf("invalid data item - %w", err)
+	}
+
+	raw, err := encodeHeader(j.SignatureType, rawSignature, rawOwner, j.Target, string(rawAnchor), &tags)
+	if err != nil {
+		return nil, err
+	}
+	d, err := Decode(append(raw, rawData...))
+	if err != nil {
+		return nil, err
+	}
+	if d.ID != j.ID {
+		return nil, errors.New("invalid data item - signature and id don't match")
+	}
+	if err = d.Verify(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// isZeroAmount reports whether a quantity or reward is empty or zero.
+func isZeroAmount(s string) bool {
+	return strings.Trim(s, "0") == ""
+}
+
+// ToBase64URL returns the binary form of a signed data item encoded as
+// base64url, for transports that cannot carry binary.
+func (d *DataItem) ToBase64URL() (string, error) {
+	if len(d.Raw) == 0 {
+		return "", errors.New("invalid data item - not signed")
+	}
+	return crypto.Base64URLEncode(d.Raw), nil
+}
+
+// FromBase64URL decodes a data item from its base64url-encoded binary form.
+//
+// Like Decode, it does not verify the signature; call Verify for that.
+func FromBase64URL(s string) (*DataItem, error) {
+	raw, err := crypto.Base64URLDecode(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data item - %w", err)
+	}
+	return Decode(raw)
+}
+
+// decodeField decodes a base64url field of a fixed size.
+func decodeField(name string, value string, size int) ([]byte, error) {
+	b, err := crypto.Base64URLDecode(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data item - %s: %w", name, err)
+	}
+	if len(b) != size {
+		return nil, fmt.Errorf("invalid data item - %s must be %d bytes, got %d", name, size, len(b))
+	}
+	return b, nil
+}
+
+// decodeOptionalField decodes a base64url field that is empty or 32 bytes.
+func decodeOptionalField(name string, value string) ([]byte, error) {
+	if value == "" {
+		return nil, nil
+	}
+	return decodeField(name, value, 32)
+}
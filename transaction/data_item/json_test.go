@@ -0,0 +1,168 @@
+package data_item
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/signer"
+	"github.com/liteseed/goar/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestJSONRoundTrip verifies signed data items survive the canonical JSON form byte for byte
+func TestJSONRoundTrip(t *testing.T) {
+	s, err := signer.FromPath("../../test/signer.json")
+	require.NoError(t, err)
+	fixture, err := os.ReadFile("../../test/1115BDataItem")
+	require.NoError(t, err)
+	fromFixture, err := Decode(fixture)
+	require.NoError(t, err)
+
+	signed := New([]byte("hello"), "OXcT1sVRSA5eGwt2k6Yuz8-3e3g9WJi5uSE99CWqsBs", "thisSentenceIs32BytesLongTrustMe", &[]tag.Tag{{Name: "App-Name", Value: "goar"}})
+	require.NoError(t, signed.Sign(s))
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ed := New(nil, "", "", nil)
+	ed.SignatureType = ED25519
+	ed.Owner = crypto.Base64URLEncode(publicKey)
+	message, err := ed.getDataItemChunk()
+	require.NoError(t, err)
+	fromED25519, err := Decode(encodeRaw(t, ed, ed25519.Sign(privateKey, message)))
+	require.NoError(t, err)
+
+	for name, d := range map[string]*DataItem{"fixture": fromFixture, "signed": signed, "ed25519": fromED25519} {
+		t.Run(name, func(t *testing.T) {
+			b, err := d.ToJSON()
+			require.NoError(t, err)
+
+			decoded, err := FromJSON(b)
+			require.NoError(t, err)
+			assert.Equal(t, d.Raw, decoded.Raw)
+			assert.Equal(t, d.ID, decoded.ID)
+
+			again, err := decoded.ToJSON()
+			require.NoError(t, err)
+			assert.Equal(t, b, again)
+		})
+	}
+}
+
+// TestToJSON verifies the canonical layout of the JSON form
+func TestToJSON(t *testing.T) {
+	fixture, err := os.ReadFile("../../test/1115BDataItem")
+	require.NoError(t, err)
+	d, err := Decode(fixture)
+	require.NoError(t, err)
+
+	b, err := d.ToJSON()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(b), `{"format":2,"id":"QpmY8mZmFEC8RxNsgbxSV6e36OF6quIYaPRKzvUco0o","last_tx":"","owner":"`))
+	assert.Contains(t, string(b), `"tags":[{"name":"Q29udGVudC1UeXBl","value":"dGV4dC9wbGFpbg"},`)
+	assert.Contains(t, string(b), `"target":"","quantity":"0","data":"NTY3MAo","data_size":"5","data_root":"","reward":"0","signature":"`)
+	assert.NotContains(t, string(b), "signature_type")
+
+	_, err = New([]byte("unsigned"), "", "", nil).ToJSON()
+	assert.Error(t, err)
+
+	// The default JSON encoding no longer dumps the binary
+	b, err = json.Marshal(d)
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "Raw")
+}
+
+// TestFromJSONGateway verifies the transaction layout of gateways is accepted
+// without the fields it does not carry
+func TestFromJSONGateway(t *testing.T) {
+	fixture, err := os.ReadFile("../../test/1115BDataItem")
+	require.NoError(t, err)
+	d, err := Decode(fixture)
+	require.NoError(t, err)
+
+	gateway, err := json.Marshal(map[string]any{
+		"id":        d.ID,
+		"last_tx":   "",
+		"owner":     d.Owner,
+		"tags":      tag.ConvertToBase64(d.Tags),
+		"target":    "",
+		"quantity":  "0",
+		"data":      d.Data,
+		"data_size": "5",
+		"reward":    "0",
+		"signature": d.Signature,
+	})
+	require.NoError(t, err)
+	decoded, err := FromJSON(gateway)
+	require.NoError(t, err)
+	assert.Equal(t, fixture, decoded.Raw)
+}
+
+// TestFromJSONInvalid verifies tampered and malformed JSON is rejected
+func TestFromJSONInvalid(t *testing.T) {
+	fixture, err := os.ReadFile("../../test/1115BDataItem")
+	require.NoError(t, err)
+	d, err := Decode(fixture)
+	require.NoError(t, err)
+	b, err := d.ToJSON()
+	require.NoError(t, err)
+
+	modify := func(f func(j *dataItemJSON)) []byte {
+		j := &dataItemJSON{}
+		require.NoError(t, json.Unmarshal(b, j))
+		f(j)
+		out, err := json.Marshal(j)
+		require.NoError(t, err)
+		return out
+	}
+	tests := []struct {
+		name string
+		json []byte
+		err  string
+	}{
+		{"not json", []byte("{"), "invalid data item"},
+		{"unsupported signature type", modify(func(j *dataItemJSON) { j.SignatureType = 9 }), "unsupported signature type"},
+		{"unsupported format", modify(func(j *dataItemJSON) { j.Format = 1 }), "unsupported format"},
+		{"quantity", modify(func(j *dataItemJSON) { j.Quantity = "1" }), "no quantity or reward"},
+		{"reward", modify(func(j *dataItemJSON) { j.Reward = "100" }), "no quantity or reward"},
+		{"wrong data size", modify(func(j *dataItemJSON) { j.DataSize = "6" }), "data_size 6 does not match 5 bytes"},
+		{"short anchor", modify(func(j *dataItemJSON) { j.LastTx = "AAAA" }), "last_tx must be 32 bytes"},
+		{"short signature", modify(func(j *dataItemJSON) { j.Signature = j.Signature[:100] }), "signature must be 512 bytes"},
+		{"short owner", modify(func(j *dataItemJSON) { j.Owner = "AAAA" }), "owner must be 512 bytes"},
+		{"short target", modify(func(j *dataItemJSON) { j.Target = "AAAA" }), "target must be 32 bytes"},
+		{"mismatched id", modify(func(j *dataItemJSON) { j.ID = crypto.Base64URLEncode(make([]byte, 32)) }), "id don't match"},
+		{"tampered data", modify(func(j *dataItemJSON) { j.Data = crypto.Base64URLEncode([]byte("5671\n")) }), "verification error"},
+		{"tampered tag", modify(func(j *dataItemJSON) { j.Tags[0].Value = crypto.Base64URLEncode([]byte("text/html")) }), "verification error"},
+		{"invalid tag", modify(func(j *dataItemJSON) { j.Tags[0].Value = "" }), "invalid data item"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FromJSON(tt.json)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+// TestBase64URL verifies the base64url form round-trips
+func TestBase64URL(t *testing.T) {
+	fixture, err := os.ReadFile("../../test/1115BDataItem")
+	require.NoError(t, err)
+	d, err := Decode(fixture)
+	require.NoError(t, err)
+
+	s, err := d.ToBase64URL()
+	require.NoError(t, err)
+	decoded, err := FromBase64URL(s)
+	require.NoError(t, err)
+	assert.Equal(t, fixture, decoded.Raw)
+
+	_, err = New(nil, "", "", nil).ToBase64URL()
+	assert.Error(t, err)
+	_, err = FromBase64URL("not base64!")
+	assert.Error(t, err)
+}
@@ -0,0 +1,288 @@
+package data_item
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/crypto/deephash"
+	"github.com/liteseed/goar/signer"
+	"github.com/liteseed/goar/tag"
+)
+
+// maxTagsSize bounds the encoded tags read by VerifyStream: MAX_TAGS tags of
+// the largest name and value, plus Avro length prefixes.
+const maxTagsSize = MAX_TAGS*(MAX_TAG_KEY_LENGTH+MAX_TAG_VALUE_LENGTH+2*binary.MaxVarintLen64) + 2*binary.MaxVarintLen64
+
+// SignStream signs a data item whose payload is streamed from r and writes the
+// binary data item to w.
+//
+// The signature precedes the payload in the binary format, so the payload is
+// read once to hash it and the signature is filled in afterwards. If w is an
+// io.WriteSeeker (e.g. an *os.File) the payload is written while it is hashed
+// and the signature is written in place at the end. Otherwise r must be an
+// io.Seeker: it is hashed, rewound and then copied to w. Either way, memory use
+// does not depend on the payload size.
+//
+// Parameters:
+//   - s: The signer
+//   - header: The data item whose target, anchor and tags are signed; its ID,
+//     Signature, SignatureType and Owner are set on success, Data and Raw are
+//     left untouched
+//   - r: The payload
+//   - size: The payload size in bytes
+//   - w: Where the binary data item is written
+//
+// Returns an error if the header is invalid, the payload does not have the
+// given size, or r or w fail.
+//
+// Example:
+//
+//	in, _ := os.Open("video.mp4")
+//	info, _ := in.Stat()
+//	out, _ := os.Create("video.item")
+//	header := data_item.New(nil, "", "", &[]tag.Tag{{Name: "Content-Type", Value: "video/mp4"}})
+//	if err := data_item.SignStream(s, header, in, info.Size(), out); err != nil {
+//		log.Fatal(err)
+//	}
+func SignStream(s signer.Interface, header *DataItem, r io.Reader, size int64, w io.Writer) error {
+	if err := tag.Validate(header.Tags); err != nil {
+		return fmt.Errorf("invalid data item - %w", err)
+	}
+	if size < 0 {
+		return fmt.Errorf("invalid data item - negative payload size %d", size)
+	}
+	rawOwner, err := crypto.Base64URLDecode(s.Owner())
+	if err != nil {
+		return err
+	}
+	signed := *header
+	signed.Owner = s.Owner()
+	signed.SignatureType = Arweave
+
+	var rawSignature []byte
+	if ws, ok := w.(io.WriteSeeker); ok && seekable(ws) {
+		rawSignature, err = signWriteSeeker(s, &signed, rawOwner, r, size, ws)
+	} else if rs, ok := r.(io.ReadSeeker); ok && seekable(rs) {
+		rawSignature, err = signReadSeeker(s, &signed, rawOwner, rs, size, w)
+	} else {
+		return errors.New("invalid data item - SignStream needs w to be an io.WriteSeeker or r to be an io.Seeker")
+	}
+	if err != nil {
+		return err
+	}
+
+	header.Owner = signed.Owner
+	header.SignatureType = signed.SignatureType
+	header.Signature = crypto.Base64URLEncode(rawSignature)
+	header.ID = crypto.Base64URLEncode(crypto.SHA256(rawSignature))
+	return nil
+}
+
+// signWriteSeeker writes the header with a blank signature, writes the payload
+// to w while hashing it, then writes the signature over the blank.
+func signWriteSeeker(s signer.Interface, d *DataItem, rawOwner []byte, r io.Reader, size int64, w io.WriteSeeker) ([]byte, error) {
+	start, err := w.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	signatureLength := SignatureConfig[Arweave].SignatureLength
+	raw, err := encodeHeader(Arweave, make([]byte, signatureLength), rawOwner, d.Target, d.Anchor, d.Tags)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(raw); err != nil {
+		return nil, err
+	}
+
+	deepHashChunk, err := d.hashDataItem(deephash.Blob(io.TeeReader(r, w), size))
+	if err != nil {
+		return nil, err
+	}
+	rawSignature, err := s.Sign(deepHashChunk)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkSignatureLength(rawSignature); err != nil {
+		return nil, err
+	}
+
+	if _, err = w.Seek(start+2, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(rawSignature); err != nil {
+		return nil, err
+	}
+	if _, err = w.Seek(start+int64(len(raw))+size, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return rawSignature, nil
+}
+
+// signReadSeeker hashes the payload, rewinds r and writes the signed data
+// item to w.
+func signReadSeeker(s signer.Interface, d *DataItem, rawOwner []byte, r io.ReadSeeker, size int64, w io.Writer) ([]byte, error) {
+	start, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	deepHashChunk, err := d.hashDataItem(deephash.Blob(r, size))
+	if err != nil {
+		return nil, err
+	}
+	rawSignature, err := s.Sign(deepHashChunk)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkSignatureLength(rawSignature); err != nil {
+		return nil, err
+	}
+
+	raw, err := encodeHeader(Arweave, rawSignature, rawOwner, d.Target, d.Anchor, d.Tags)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = r.Seek(start, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(raw); err != nil {
+		return nil, err
+	}
+	if _, err = io.CopyN(w, r, size); err != nil {
+		return nil, err
+	}
+	return rawSignature, nil
+}
+
+// checkSignatureLength ensures a signature fits the space reserved for it in
+// the binary format.
+func checkSignatureLength(rawSignature []byte) error {
+	if expected := SignatureConfig[Arweave].SignatureLength; len(rawSignature) != expected {
+		return fmt.Errorf("invalid data item - signature must be %d bytes, got %d", expected, len(rawSignature))
+	}
+	return nil
+}
+
+// seekable reports whether s supports seeking; files such as pipes implement
+// io.Seeker but fail on every call.
+func seekable(s io.Seeker) bool {
+	_, err := s.Seek(0, io.SeekCurrent)
+	return err == nil
+}
+
+// VerifyStream verifies a binary data item read from r without loading its
+// payload into memory.
+//
+// Parameters:
+//   - r: The binary data item, e.g. an *os.File
+//   - size: The size of the data item in bytes
+//
+// Returns the data item without its payload (Data and Raw are empty), or an
+// error if the data item is malformed or its signature is invalid.
+//
+// Example:
+//
+//	f, _ := os.Open("video.item")
+//	info, _ := f.Stat()
+//	d, err := data_item.VerifyStream(f, info.Size())
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Printf("Valid data item %s\n", d.ID)
+func VerifyStream(r io.ReaderAt, size int64) (*DataItem, error) {
+	d, dataStart, err := decodeHeader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	dataSize := size - dataStart
+	if err = d.verify(deephash.Blob(io.NewSectionReader(r, dataStart, dataSize), dataSize)); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// decodeHeader decodes the fields preceding the payload of a binary data
+// item. It returns the data item and the offset of the payload.
+func decodeHeader(r io.ReaderAt, size int64) (*DataItem, int64, error) {
+	var position int64
+	read := func(n int64) ([]byte, error) {
+		if n > size-position {
+			return nil, errors.New("invalid data item - binary too small")
+		}
+		b := make([]byte, n)
+		if _, err := r.ReadAt(b, position); err != nil {
+			return nil, err
+		}
+		position += n
+		return b, nil
+	}
+
+	rawSignatureType, err := read(2)
+	if err != nil {
+		return nil, 0, err
+	}
+	signatureType, signatureLength, publicKeyLength, err := getSignatureMetadata(rawSignatureType)
+	if err != nil {
+		return nil, 0, err
+	}
+	rawSignature, err := read(int64(signatureLength))
+	if err != nil {
+		return nil, 0, err
+	}
+	rawOwner, err := read(int64(publicKeyLength))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Target and anchor are 32 bytes preceded by a presence byte
+	optional := func() ([]byte, error) {
+		present, err := read(1)
+		if err != nil {
+			return nil, err
+		}
+		switch present[0] {
+		case 0:
+			return nil, nil
+		case 1:
+			return read(32)
+		}
+		return nil, fmt.Errorf("invalid data item - invalid presence byte %d", present[0])
+	}
+	rawTarget, err := optional()
+	if err != nil {
+		return nil, 0, err
+	}
+	rawAnchor, err := optional()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tagsHeader, err := read(16)
+	if err != nil {
+		return nil, 0, err
+	}
+	tagsSize := binary.LittleEndian.Uint64(tagsHeader[8:])
+	if tagsSize > maxTagsSize {
+		return nil, 0, fmt.Errorf("invalid data item - tags larger than %d bytes", maxTagsSize)
+	}
+	rawTags, err := read(int64(tagsSize))
+	if err != nil {
+		return nil, 0, err
+	}
+	tags, _, err := tag.Deserialize(append(tagsHeader, rawTags...), 0)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &DataItem{
+		ID:            crypto.Base64URLEncode(crypto.SHA256(rawSignature)),
+		SignatureType: signatureType,
+		Signature:     crypto.Base64URLEncode(rawSignature),
+		Owner:         crypto.Base64URLEncode(rawOwner),
+		Target:        crypto.Base64URLEncode(rawTarget),
+		Anchor:        string(rawAnchor),
+		Tags:          tags,
+	}, position, nil
+}
@@ -0,0 +1,141 @@
+package data_item
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/signer"
+	"github.com/liteseed/goar/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// zeroReader returns zero bytes forever
+type zeroReader struct{}
+
+func (zeroReader) Read(b []byte) (int, error) {
+	clear(b)
+	return len(b), nil
+}
+
+// TestSignStreamFile verifies a data item streamed into a file decodes and verifies
+func TestSignStreamFile(t *testing.T) {
+	s, err := signer.FromPath("../../test/signer.json")
+	require.NoError(t, err)
+
+	data := []byte("streamed payload")
+	tags := &[]tag.Tag{{Name: "Content-Type", Value: "text/plain"}}
+	header := New(nil, "OXcT1sVRSA5eGwt2k6Yuz8-3e3g9WJi5uSE99CWqsBs", "thisSentenceIs32BytesLongTrustMe", tags)
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "item"))
+	require.NoError(t, err)
+	defer f.Close()
+	// An io.Reader without Seek forces writing the signature in place
+	require.NoError(t, SignStream(s, header, io.MultiReader(bytes.NewReader(data)), int64(len(data)), f))
+
+	raw, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+	d, err := Decode(raw)
+	require.NoError(t, err)
+	assert.NoError(t, d.Verify())
+	assert.Equal(t, header.ID, d.ID)
+	assert.Equal(t, header.Signature, d.Signature)
+	assert.Equal(t, s.Owner(), header.Owner)
+	assert.Equal(t, crypto.Base64URLEncode(data), d.Data)
+	assert.Equal(t, *tags, *d.Tags)
+	assert.Empty(t, header.Data)
+}
+
+// TestSignStreamSeekableReader verifies a seekable payload is streamed to any writer
+func TestSignStreamSeekableReader(t *testing.T) {
+	s, err := signer.FromPath("../../test/signer.json")
+	require.NoError(t, err)
+
+	data := []byte("streamed payload")
+	header := New(nil, "", "", nil)
+	out := &bytes.Buffer{}
+	require.NoError(t, SignStream(s, header, bytes.NewReader(data), int64(len(data)), out))
+
+	d, err := Decode(out.Bytes())
+	require.NoError(t, err)
+	assert.NoError(t, d.Verify())
+	assert.Equal(t, header.ID, d.ID)
+	assert.Equal(t, crypto.Base64URLEncode(data), d.Data)
+}
+
+// TestSignStreamErrors verifies invalid sizes, tags and unseekable streams are rejected
+func TestSignStreamErrors(t *testing.T) {
+	s, err := signer.FromPath("../../test/signer.json")
+	require.NoError(t, err)
+	data := []byte("payload")
+
+	// Neither side can seek
+	err = SignStream(s, New(nil, "", "", nil), io.MultiReader(bytes.NewReader(data)), int64(len(data)), &bytes.Buffer{})
+	assert.ErrorContains(t, err, "io.Seeker")
+
+	// Payload shorter and longer than declared
+	err = SignStream(s, New(nil, "", "", nil), bytes.NewReader(data), int64(len(data))+1, &bytes.Buffer{})
+	assert.Error(t, err)
+	err = SignStream(s, New(nil, "", "", nil), bytes.NewReader(data), int64(len(data))-1, &bytes.Buffer{})
+	assert.Error(t, err)
+
+	err = SignStream(s, New(nil, "", "", nil), bytes.NewReader(data), -1, &bytes.Buffer{})
+	assert.Error(t, err)
+
+	header := New(nil, "", "", &[]tag.Tag{{Name: "", Value: "value"}})
+	err = SignStream(s, header, bytes.NewReader(data), int64(len(data)), &bytes.Buffer{})
+	assert.Error(t, err)
+	assert.Empty(t, header.ID)
+}
+
+// TestVerifyStream verifies binary data items read through an io.ReaderAt
+func TestVerifyStream(t *testing.T) {
+	raw, err := os.ReadFile("../../test/1115BDataItem")
+	require.NoError(t, err)
+
+	d, err := VerifyStream(bytes.NewReader(raw), int64(len(raw)))
+	require.NoError(t, err)
+	assert.Equal(t, "QpmY8mZmFEC8RxNsgbxSV6e36OF6quIYaPRKzvUco0o", d.ID)
+	assert.Equal(t, "", d.Target)
+	assert.Equal(t, "", d.Anchor)
+	assert.Len(t, *d.Tags, 3)
+	assert.Empty(t, d.Data)
+
+	// Tampered payload
+	tampered := bytes.Clone(raw)
+	tampered[len(tampered)-1] ^= 1
+	_, err = VerifyStream(bytes.NewReader(tampered), int64(len(tampered)))
+	assert.Error(t, err)
+
+	// Truncated headers
+	for _, n := range []int{0, 1, 2, 600, 1100} {
+		_, err = VerifyStream(bytes.NewReader(raw[:n]), int64(n))
+		assert.Error(t, err, n)
+	}
+}
+
+// TestStreamLargePayload verifies a payload much larger than the read buffers round-trips through files
+func TestStreamLargePayload(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping large payload in short mode")
+	}
+	s, err := signer.FromPath("../../test/signer.json")
+	require.NoError(t, err)
+
+	const size = 64 << 20
+	f, err := os.Create(filepath.Join(t.TempDir(), "item"))
+	require.NoError(t, err)
+	defer f.Close()
+	header := New(nil, "", "", &[]tag.Tag{{Name: "Content-Type", Value: "application/octet-stream"}})
+	require.NoError(t, SignStream(s, header, io.LimitReader(zeroReader{}, size), size, f))
+
+	info, err := f.Stat()
+	require.NoError(t, err)
+	d, err := VerifyStream(f, info.Size())
+	require.NoError(t, err)
+	assert.Equal(t, header.ID, d.ID)
+}
@@ -11,5 +11,5 @@ type DataItem struct {
 	Anchor        string     `json:"anchor"`
 	Tags          *[]tag.Tag `json:"tags"`
 	Data          string     `json:"data"`
-	Raw           []byte
+	Raw           []byte     `json:"-"`
 }
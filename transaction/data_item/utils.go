@@ -4,16 +4,18 @@ import (
 	"encoding/base64"
 	"encoding/binary"
 	"fmt"
+	"strconv"
 
 	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/crypto/deephash"
 	"github.com/liteseed/goar/tag"
 )
 
 const (
-	Arweave  = 1
-	ED25519  = 2
-	Ethereum = 3
-	Solana   = 4
+	Arweave  = crypto.SIGNATURE_TYPE_ARWEAVE
+	ED25519  = crypto.SIGNATURE_TYPE_ED25519
+	Ethereum = crypto.SIGNATURE_TYPE_ETHEREUM
+	Solana   = crypto.SIGNATURE_TYPE_SOLANA
 )
 
 type SignatureMeta struct {
@@ -45,23 +47,39 @@ var SignatureConfig = map[int]SignatureMeta{
 	},
 }
 
-func getTarget(data *[]byte, position int) (string, int) {
-	target := ""
-	if (*data)[position] == 1 {
-		target = base64.RawURLEncoding.EncodeToString((*data)[position+1 : position+1+32])
-		position += 32
+func getTarget(data *[]byte, position int) (string, int, error) {
+	rawTarget, position, err := getOptional(*data, position, "target")
+	if err != nil {
+		return "", position, err
+	}
+	return base64.RawURLEncoding.EncodeToString(rawTarget), position, nil
+}
+
+func getAnchor(data *[]byte, position int) (string, int, error) {
+	rawAnchor, position, err := getOptional(*data, position, "anchor")
+	if err != nil {
+		return "", position, err
 	}
-	return target, position + 1
+	return string(rawAnchor), position, nil
 }
 
-func getAnchor(data *[]byte, position int) (string, int) {
-	anchor := ""
-	if (*data)[position] == 1 {
-		anchor = string((*data)[position+1 : position+1+32])
-		position += 32
+// getOptional reads a 32-byte field preceded by a presence byte of 0 or 1.
+func getOptional(data []byte, position int, name string) ([]byte, int, error) {
+	if position >= len(data) {
+		return nil, position, fmt.Errorf("invalid data item - truncated %s presence byte", name)
+	}
+	switch data[position] {
+	case 0:
+		return nil, position + 1, nil
+	case 1:
+		if len(data)-position-1 < 32 {
+			return nil, position, fmt.Errorf("invalid data item - truncated %s", name)
+		}
+		return data[position+1 : position+1+32], position + 1 + 32, nil
 	}
-	return anchor, position + 1
+	return nil, position, fmt.Errorf("invalid data item - invalid %s presence byte %d", name, data[position])
 }
+
 func getSignatureMetadata(data []byte) (SignatureType int, SignatureLength int, PublicKeyLength int, err error) {
 	SignatureType = int(binary.LittleEndian.Uint16(data))
 	signatureMeta, ok := SignatureConfig[SignatureType]
@@ -76,6 +94,16 @@ func getSignatureMetadata(data []byte) (SignatureType int, SignatureLength int,
 
 // This function assembles DataItem data in a format specified by ANS-104 and hashes is it using DeepHash
 func (d *DataItem) getDataItemChunk() ([]byte, error) {
+	rawData, err := crypto.Base64URLDecode(d.Data)
+	if err != nil {
+		return nil, err
+	}
+	return d.hashDataItem(deephash.Bytes(rawData))
+}
+
+// hashDataItem returns the deep hash of the data item fields with the given
+// payload chunk, so the payload can be in memory or streamed.
+func (d *DataItem) hashDataItem(data deephash.Chunk) ([]byte, error) {
 	rawOwner, err := crypto.Base64URLDecode(d.Owner)
 	if err != nil {
 		return nil, err
@@ -91,21 +119,61 @@ func (d *DataItem) getDataItemChunk() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	rawData, err := crypto.Base64URLDecode(d.Data)
+
+	signatureType := d.SignatureType
+	if signatureType == 0 {
+		signatureType = Arweave
+	}
+
+	deepHashChunk, err := deephash.Hash(deephash.List(
+		deephash.Bytes([]byte("dataitem")),
+		deephash.Bytes([]byte("1")),
+		deephash.Bytes([]byte(strconv.Itoa(signatureType))),
+		deephash.Bytes(rawOwner),
+		deephash.Bytes(rawTarget),
+		deephash.Bytes(rawAnchor),
+		deephash.Bytes(rawTags),
+		data,
+	))
 	if err != nil {
 		return nil, err
 	}
+	return deepHashChunk[:], nil
+}
 
-	chunks := [][]byte{
-		[]byte("dataitem"),
-		[]byte("1"),
-		[]byte("1"),
-		rawOwner,
-		rawTarget,
-		rawAnchor,
-		rawTags,
-		rawData,
+// encodeHeader encodes the fields of a data item that precede the payload.
+func encodeHeader(signatureType int, rawSignature []byte, rawOwner []byte, target string, anchor string, tags *[]tag.Tag) ([]byte, error) {
+	rawTarget, err := crypto.Base64URLDecode(target)
+	if err != nil {
+		return nil, err
 	}
-	deepHashChunk := crypto.DeepHash(chunks)
-	return deepHashChunk[:], nil
+	rawAnchor := []byte(anchor)
+
+	rawTags, err := tag.Serialize(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make([]byte, 0)
+	raw = binary.LittleEndian.AppendUint16(raw, uint16(signatureType))
+	raw = append(raw, rawSignature...)
+	raw = append(raw, rawOwner...)
+
+	if target == "" {
+		raw = append(raw, 0)
+	} else {
+		raw = append(raw, 1)
+	}
+	raw = append(raw, rawTarget...)
+
+	if anchor == "" {
+		raw = append(raw, 0)
+	} else {
+		raw = append(raw, 1)
+	}
+	raw = append(raw, rawAnchor...)
+	raw = binary.LittleEndian.AppendUint64(raw, uint64(len(*tags)))
+	raw = binary.LittleEndian.AppendUint64(raw, uint64(len(rawTags)))
+	raw = append(raw, rawTags...)
+	return raw, nil
 }
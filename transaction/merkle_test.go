@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/currency"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -27,9 +28,9 @@ func TestMerkle(t *testing.T) {
 		require.NoError(t, err)
 
 		// Create transaction and prepare chunks
-		tx := New(data, "", "", nil)
+		tx := New(data, "", currency.Zero, nil)
 		tx.LastTx = "foo"
-		tx.Reward = "1"
+		tx.Reward = currency.NewWinston(1)
 
 		err = tx.PrepareChunks(data)
 		require.NoError(t, err)
@@ -66,9 +67,9 @@ func TestMerkle(t *testing.T) {
 		require.NoError(t, err)
 
 		// Create transaction and prepare chunks
-		tx := New(data, "", "", nil)
+		tx := New(data, "", currency.Zero, nil)
 		tx.LastTx = "foo"
-		tx.Reward = "1"
+		tx.Reward = currency.NewWinston(1)
 
 		err = tx.PrepareChunks(data)
 		require.NoError(t, err)
@@ -0,0 +1,228 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/currency"
+	"github.com/liteseed/goar/signer"
+)
+
+// Portable unsigned transaction format constants.
+const (
+	UNSIGNED_TYPE    = "arweave/unsigned-transaction" // Value of the "type" field
+	UNSIGNED_VERSION = 1                              // Supported format version
+)
+
+// Unsigned is the portable representation of a transaction waiting to be
+// signed on another machine.
+//
+// The transaction carries every field covered by the signature, including
+// the data root and size, but not the data itself, so it stays small
+// regardless of the payload. SignatureData is included so the signing
+// machine can check that it computes the same message before signing.
+type Unsigned struct {
+	Type          string       `json:"type"`           // Always UNSIGNED_TYPE
+	Version       int          `json:"version"`        // Format version
+	Transaction   *Transaction `json:"transaction"`    // Transaction header without data or signature
+	SignatureData string       `json:"signature_data"` // Base64url-encoded deep hash to be signed
+}
+
+// SignatureData returns the message that is signed to produce the
+// transaction signature.
+//
+// Returns the deep hash of the signed transaction fields, or an error if the
+// transaction format is unsupported or if any field cannot be decoded.
+func (tx *Transaction) SignatureData() ([]byte, error) {
+	return tx.getSignatureData()
+}
+
+// ExportUnsigned exports the transaction for offline signing.
+//
+// The owner, anchor (LastTx) and reward must already be set. The data is
+// chunked to compute the data root and then left out of the export.
+//
+// Returns the portable JSON, or an error if the transaction is incomplete or
+// already signed.
+//
+// Example:
+//
+//	// Online host
+//	b, err := tx.ExportUnsigned()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	os.WriteFile("unsigned.json", b, 0o644)
+func (tx *Transaction) ExportUnsigned() ([]byte, error) {
+	if tx.Signature != "" {
+		return nil, errors.New("transaction is already signed")
+	}
+	if tx.Owner == "" {
+		return nil, errors.New("transaction owner is not set")
+	}
+	if tx.LastTx == "" {
+		return nil, errors.New("transaction anchor is not set")
+	}
+
+	signatureData, err := tx.getSignatureData()
+	if err != nil {
+		return nil, err
+	}
+	header := *tx
+	header.Data = ""
+	header.ChunkData = nil
+	return json.Marshal(&Unsigned{
+		Type:          UNSIGNED_TYPE,
+		Version:       UNSIGNED_VERSION,
+		Transaction:   &header,
+		SignatureData: crypto.Base64URLEncode(signatureData),
+	})
+}
+
+// ImportUnsigned imports a transaction exported with ExportUnsigned.
+//
+// The signature data is recomputed from the transaction fields and must match
+// the exported signature data. This catches a corrupted export, but not one
+// whose fields and signature data were both changed: check the Summary of the
+// transaction before signing it, or use SignUnsigned.
+//
+// Parameters:
+//   - b: The portable JSON
+//
+// Returns the header-only transaction ready to be signed with Sign, or an
+// error if the export is invalid.
+//
+// Example:
+//
+//	// Air-gapped host
+//	tx, err := transaction.ImportUnsigned(b)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(tx.Summary())
+//	if err = tx.Sign(s); err != nil {
+//		log.Fatal(err)
+//	}
+//	signed, err := json.Marshal(tx)
+func ImportUnsigned(b []byte) (*Transaction, error) {
+	u := &Unsigned{}
+	if err := json.Unmarshal(b, u); err != nil {
+		return nil, err
+	}
+	if u.Type != UNSIGNED_TYPE {
+		return nil, fmt.Errorf("invalid unsigned transaction type: %q", u.Type)
+	}
+	if u.Version != UNSIGNED_VERSION {
+		return nil, fmt.Errorf("unsupported unsigned transaction version: %d", u.Version)
+	}
+	tx := u.Transaction
+	if tx == nil {
+		return nil, errors.New("invalid unsigned transaction - missing transaction")
+	}
+	if tx.Signature != "" || tx.ID != "" {
+		return nil, errors.New("invalid unsigned transaction - already signed")
+	}
+	if tx.Data != "" {
+		return nil, errors.New("invalid unsigned transaction - data must not be included")
+	}
+
+	signatureData, err := tx.getSignatureData()
+	if err != nil {
+		return nil, err
+	}
+	expected, err := crypto.Base64URLDecode(u.SignatureData)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(signatureData, expected) {
+		return nil, errors.New("invalid unsigned transaction - signature data does not match the transaction")
+	}
+	return tx, nil
+}
+
+// Summary lists the transaction fields a signer confirms before signing.
+type Summary struct {
+	Owner    string           // Wallet address of the owner
+	Target   string           // Target wallet address, empty if no AR is transferred
+	Quantity currency.Winston // Amount of AR transferred to the target
+	Reward   currency.Winston // Transaction fee
+	DataRoot string           // Base64url-encoded Merkle root of the data, empty if there is no data
+	DataSize string           // Size of the data in bytes
+}
+
+// Summary returns the fields of the transaction to confirm before signing it.
+//
+// Returns an error if the owner is not a valid public key.
+func (tx *Transaction) Summary() (*Summary, error) {
+	address, err := crypto.GetAddressFromOwner(tx.Owner)
+	if err != nil {
+		return nil, err
+	}
+	return &Summary{
+		Owner:    address,
+		Target:   tx.Target,
+		Quantity: tx.Quantity,
+		Reward:   tx.Reward,
+		DataRoot: tx.DataRoot,
+		DataSize: tx.DataSize,
+	}, nil
+}
+
+// String formats the summary for display, one field per line.
+func (s *Summary) String() string {
+	return fmt.Sprintf("owner: %s\ntarget: %s\nquantity: %s AR\nreward: %s AR\ndata_root: %s\ndata_size: %s",
+		s.Owner, s.Target, s.Quantity.AR(), s.Reward.AR(), s.DataRoot, s.DataSize)
+}
+
+// SignUnsigned signs a transaction exported with ExportUnsigned once its
+// summary is confirmed.
+//
+// This is the offline step of the air-gapped workflow. The export is imported
+// with ImportUnsigned and its Summary is passed to confirm, which should show
+// it to the operator; the transaction is only signed if confirm returns nil.
+//
+// Parameters:
+//   - b: The portable JSON
+//   - s: The signer, whose owner must be the owner of the transaction
+//   - confirm: Shows the summary and returns an error to refuse signing
+//
+// Returns the signed transaction JSON without data, or an error if the export
+// is invalid, the signer does not own the transaction or confirm refuses it.
+//
+// Example:
+//
+//	signed, err := transaction.SignUnsigned(b, s, func(sum *transaction.Summary) error {
+//		fmt.Println(sum)
+//		fmt.Print("sign? [y/N] ")
+//		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+//		if strings.TrimSpace(answer) != "y" {
+//			return errors.New("refused")
+//		}
+//		return nil
+//	})
+func SignUnsigned(b []byte, s signer.Interface, confirm func(*Summary) error) ([]byte, error) {
+	if confirm == nil {
+		return nil, errors.New("confirm is nil")
+	}
+	tx, err := ImportUnsigned(b)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Owner != s.Owner() {
+		return nil, errors.New("signer does not own the transaction")
+	}
+	summary, err := tx.Summary()
+	if err != nil {
+		return nil, err
+	}
+	if err = confirm(summary); err != nil {
+		return nil, err
+	}
+	if err = tx.Sign(s); err != nil {
+		return nil, err
+	}
+	return json.Marshal(tx)
+}
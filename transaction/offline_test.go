@@ -0,0 +1,158 @@
+package transaction
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/currency"
+	"github.com/liteseed/goar/signer"
+	"github.com/liteseed/goar/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newUnsignedTransaction(t *testing.T, s *signer.Signer, data []byte) *Transaction {
+	tx := New(data, "", currency.Zero, &[]tag.Tag{tag.ContentType("application/octet-stream")})
+	tx.Owner = s.Owner()
+	tx.LastTx = crypto.Base64URLEncode(crypto.SHA256([]byte("anchor")))
+	tx.Reward = currency.NewWinston(1000)
+	return tx
+}
+
+func TestOfflineRoundTrip(t *testing.T) {
+	s, err := signer.FromPath("../test/signer.json")
+	require.NoError(t, err)
+
+	for _, size := range []int{0, 1024, 3*MAX_CHUNK_SIZE + 7} {
+		data := make([]byte, size)
+		for i := range data {
+			data[i] = byte(i * 31)
+		}
+
+		tx := newUnsignedTransaction(t, s, data)
+		expected, err := tx.SignatureData()
+		require.NoError(t, err)
+
+		b, err := tx.ExportUnsigned()
+		require.NoError(t, err)
+		assert.Less(t, len(b), 4096, "data is not exported")
+
+		offline, err := ImportUnsigned(b)
+		require.NoError(t, err)
+		actual, err := offline.SignatureData()
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual, "signature data is stable across export")
+
+		require.NoError(t, offline.Sign(s))
+		assert.NoError(t, offline.Verify(), "header-only transactions verify")
+
+		signed, err := json.Marshal(offline)
+		require.NoError(t, err)
+		back := &Transaction{}
+		require.NoError(t, json.Unmarshal(signed, back))
+		assert.Equal(t, offline.ID, back.ID)
+		assert.NoError(t, back.Verify())
+
+		// Attaching the data keeps the ID valid
+		back.Data = crypto.Base64URLEncode(data)
+		assert.NoError(t, back.Verify())
+		assert.Equal(t, tx.DataRoot, back.DataRoot)
+	}
+}
+
+func TestImportUnsignedRejects(t *testing.T) {
+	s, err := signer.FromPath("../test/signer.json")
+	require.NoError(t, err)
+
+	tx := newUnsignedTransaction(t, s, []byte("hello"))
+	b, err := tx.ExportUnsigned()
+	require.NoError(t, err)
+
+	t.Run("Tampered reward", func(t *testing.T) {
+		tampered := strings.Replace(string(b), `"reward":"1000"`, `"reward":"999999"`, 1)
+		require.NotEqual(t, string(b), tampered)
+		_, err := ImportUnsigned([]byte(tampered))
+		assert.Error(t, err)
+	})
+
+	t.Run("Wrong type", func(t *testing.T) {
+		_, err := ImportUnsigned([]byte(strings.Replace(string(b), UNSIGNED_TYPE, "other", 1)))
+		assert.Error(t, err)
+	})
+
+	t.Run("Wrong version", func(t *testing.T) {
+		_, err := ImportUnsigned([]byte(strings.Replace(string(b), `"version":1`, `"version":2`, 1)))
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		_, err := ImportUnsigned([]byte("{"))
+		assert.Error(t, err)
+	})
+
+	t.Run("Export signed", func(t *testing.T) {
+		signed := newUnsignedTransaction(t, s, []byte("hello"))
+		require.NoError(t, signed.Sign(s))
+		_, err := signed.ExportUnsigned()
+		assert.Error(t, err)
+	})
+
+	t.Run("Export without anchor", func(t *testing.T) {
+		unanchored := newUnsignedTransaction(t, s, []byte("hello"))
+		unanchored.LastTx = ""
+		_, err := unanchored.ExportUnsigned()
+		assert.Error(t, err)
+	})
+}
+
+// TestSignUnsigned verifies the summary is confirmed before an export is signed
+func TestSignUnsigned(t *testing.T) {
+	s, err := signer.FromPath("../test/signer.json")
+	require.NoError(t, err)
+	other, err := signer.New()
+	require.NoError(t, err)
+
+	tx := newUnsignedTransaction(t, s, []byte("hello"))
+	tx.Target = other.Address
+	tx.Quantity = currency.NewWinston(5)
+	b, err := tx.ExportUnsigned()
+	require.NoError(t, err)
+
+	t.Run("Confirmed", func(t *testing.T) {
+		var summary *Summary
+		signed, err := SignUnsigned(b, s, func(sum *Summary) error {
+			summary = sum
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, s.Address, summary.Owner)
+		assert.Equal(t, tx.Target, summary.Target)
+		assert.Equal(t, "5", summary.Quantity.String())
+		assert.Equal(t, "1000", summary.Reward.String())
+		assert.Equal(t, tx.DataRoot, summary.DataRoot)
+		assert.Equal(t, "5", summary.DataSize)
+		assert.Contains(t, summary.String(), "data_root: "+tx.DataRoot)
+
+		back := &Transaction{}
+		require.NoError(t, json.Unmarshal(signed, back))
+		assert.NoError(t, back.Verify())
+	})
+
+	t.Run("Refused", func(t *testing.T) {
+		signed, err := SignUnsigned(b, s, func(*Summary) error { return assert.AnError })
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, signed)
+	})
+
+	t.Run("Other signer", func(t *testing.T) {
+		_, err := SignUnsigned(b, other, func(*Summary) error { return nil })
+		assert.Error(t, err)
+	})
+
+	t.Run("No confirm", func(t *testing.T) {
+		_, err := SignUnsigned(b, s, nil)
+		assert.Error(t, err)
+	})
+}
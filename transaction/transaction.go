@@ -7,7 +7,7 @@
 //
 //	data := []byte("Hello, Arweave!")
 //	tags := []tag.Tag{{Name: "Content-Type", Value: "text/plain"}}
-//	tx := transaction.New(data, "", "0", &tags)
+//	tx := transaction.New(data, "", currency.Zero, &tags)
 //
 //	signer := wallet.Signer()
 //	err := tx.Sign(signer)
@@ -20,6 +20,7 @@ import (
 	"errors"
 
 	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/currency"
 	"github.com/liteseed/goar/signer"
 	"github.com/liteseed/goar/tag"
 )
@@ -29,7 +30,7 @@ import (
 // Parameters:
 //   - data: The data to include in the transaction. Can be nil for transactions without data.
 //   - target: The target wallet address for AR transfers. Use empty string for data-only transactions.
-//   - quantity: The amount of AR to transfer in Winston units. Use "0" for data-only transactions.
+//   - quantity: The amount of AR to transfer. Use currency.Zero for data-only transactions.
 //   - tags: Optional metadata tags for the transaction. Can be nil.
 //
 // Returns a new Transaction struct with format version 2, which is the current
@@ -39,17 +40,15 @@ import (
 //
 //	// Data transaction with tags
 //	tags := []tag.Tag{{Name: "Content-Type", Value: "application/json"}}
-//	tx := New(jsonData, "", "0", &tags)
+//	tx := New(jsonData, "", currency.Zero, &tags)
 //
 //	// AR transfer transaction
-//	tx := New(nil, targetAddress, "1000000000000", nil) // 1 AR in Winston
-func New(data []byte, target string, quantity string, tags *[]tag.Tag) *Transaction {
+//	amount, _ := currency.ParseAR("1")
+//	tx := New(nil, targetAddress, amount, nil)
+func New(data []byte, target string, quantity currency.Winston, tags *[]tag.Tag) *Transaction {
 	if tags == nil {
 		tags = &[]tag.Tag{}
 	}
-	if quantity == "" {
-		quantity = "0"
-	}
 	if data == nil {
 		data = []byte("")
 	}
@@ -67,12 +66,12 @@ func New(data []byte, target string, quantity string, tags *[]tag.Tag) *Transact
 //
 // This method:
 // 1. Generates the signature data from the transaction fields
-// 2. Signs the data using the signer's private key
+// 2. Signs the data with the signer
 // 3. Sets the transaction ID as the SHA256 hash of the signature
 // 4. Sets the signature field with the base64url-encoded signature
 //
 // Parameters:
-//   - s: A signer containing the private key to sign with
+//   - s: The signer, either a local signer.Signer or a remote signer
 //
 // Returns an error if signing fails or if the transaction format is unsupported.
 //
@@ -85,12 +84,12 @@ func New(data []byte, target string, quantity string, tags *[]tag.Tag) *Transact
 //		return err
 //	}
 //	fmt.Printf("Transaction signed with ID: %s", tx.ID)
-func (tx *Transaction) Sign(s *signer.Signer) error {
+func (tx *Transaction) Sign(s signer.Interface) error {
 	payload, err := tx.getSignatureData()
 	if err != nil {
 		return err
 	}
-	rawSignature, err := crypto.Sign(payload, s.PrivateKey)
+	rawSignature, err := s.Sign(payload)
 	if err != nil {
 		return err
 	}
@@ -103,7 +102,7 @@ func (tx *Transaction) Sign(s *signer.Signer) error {
 //
 // This method:
 // 1. Regenerates the signature data from the transaction fields
-// 2. Extracts the public key from the Owner field
+// 2. Builds an RSA verifier from the Owner field
 // 3. Verifies the signature against the data using the public key
 //
 // Returns nil if the signature is valid, or an error if verification fails.
@@ -126,11 +125,29 @@ func (tx *Transaction) Verify() error {
 	if err != nil {
 		return err
 	}
-	publicKey, err := crypto.GetPublicKeyFromOwner(tx.Owner)
+	rawOwner, err := crypto.Base64URLDecode(tx.Owner)
+	if err != nil {
+		return err
+	}
+	verifier, err := crypto.NewVerifier(crypto.SIGNATURE_TYPE_ARWEAVE, rawOwner)
 	if err != nil {
 		return err
 	}
-	return crypto.Verify(signatureData, rawSignature, publicKey)
+	return verifier.Verify(signatureData, rawSignature)
+}
+
+// MatchTags reports whether the tags of the transaction satisfy a query.
+//
+// Transaction tags are base64url-encoded, so they are decoded before they are
+// matched; use it instead of choosing between Match and MatchBase64.
+//
+// Returns true if the tags match, or an error if a tag cannot be decoded.
+//
+// Example:
+//
+//	ok, err := tx.MatchTags(tag.MustCompile(`App-Name = ArDrive`))
+func (tx *Transaction) MatchTags(q *tag.Query) (bool, error) {
+	return q.MatchBase64(tx.Tags)
 }
 
 // getSignatureData generates the data that should be signed for this transaction.
@@ -144,7 +161,7 @@ func (tx *Transaction) Verify() error {
 // - Owner (public key)
 // - Target address
 // - Quantity in Winston
-// - Reward amount
+// - Reward in Winston
 // - Last transaction hash
 // - Tags
 // - Data size
@@ -175,14 +192,18 @@ func (tx *Transaction) getSignatureData() ([]byte, error) {
 		return nil, err
 	}
 
-	data, err := crypto.Base64URLDecode(tx.Data)
-	if err != nil {
-		return nil, err
-	}
+	// Header-only transactions have no data but keep the data root and size
+	// computed before the data was removed
+	if tx.Data != "" || tx.DataRoot == "" {
+		data, err := crypto.Base64URLDecode(tx.Data)
+		if err != nil {
+			return nil, err
+		}
 
-	err = tx.PrepareChunks(data)
-	if err != nil {
-		return nil, err
+		err = tx.PrepareChunks(data)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	rawDataRoot, err := crypto.Base64URLDecode(tx.DataRoot)
@@ -194,8 +215,8 @@ func (tx *Transaction) getSignatureData() ([]byte, error) {
 		[]byte("2"),
 		rawOwner,
 		rawTarget,
-		[]byte(tx.Quantity),
-		[]byte(tx.Reward),
+		[]byte(tx.Quantity.String()),
+		[]byte(tx.Reward.String()),
 		rawLastTx,
 		rawTags,
 		[]byte(tx.DataSize),
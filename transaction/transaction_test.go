@@ -4,6 +4,7 @@ package transaction
 import (
 	"testing"
 
+	"github.com/liteseed/goar/currency"
 	"github.com/liteseed/goar/signer"
 	"github.com/liteseed/goar/tag"
 	"github.com/stretchr/testify/assert"
@@ -18,13 +19,13 @@ func TestSign(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("Sign basic transaction", func(t *testing.T) {
-		tx := New(data, "", "0", nil)
+		tx := New(data, "", currency.Zero, nil)
 		require.NotNil(t, tx)
 
 		// Set required fields for signing
 		tx.Owner = s.Owner()
 		tx.LastTx = "lqsw6xgaaunfs8h3d6n54ci1lgm2tmtqvz3wke9v9ygq64q8s68yz2jfq5xy4nec"
-		tx.Reward = "1000"
+		tx.Reward = currency.NewWinston(1000)
 
 		// Sign the transaction
 		err = tx.Sign(s)
@@ -45,13 +46,13 @@ func TestSign(t *testing.T) {
 			{Name: "test", Value: "1"},
 			{Name: "test", Value: "test"},
 		}
-		tx := New(data, "", "0", tags)
+		tx := New(data, "", currency.Zero, tags)
 		require.NotNil(t, tx)
 
 		// Set required fields for signing
 		tx.Owner = s.Owner()
 		tx.LastTx = "lqsw6xgaaunfs8h3d6n54ci1lgm2tmtqvz3wke9v9ygq64q8s68yz2jfq5xy4nec"
-		tx.Reward = "1000"
+		tx.Reward = currency.NewWinston(1000)
 
 		// Sign the transaction
 		err = tx.Sign(s)
@@ -73,19 +74,20 @@ func TestSign(t *testing.T) {
 func TestNew(t *testing.T) {
 	t.Run("Create transaction with data", func(t *testing.T) {
 		data := []byte("hello world")
-		tx := New(data, "", "0", nil)
+		tx := New(data, "", currency.Zero, nil)
 
 		assert.Equal(t, 2, tx.Format)
 		assert.NotEmpty(t, tx.Data)
 		assert.Equal(t, "", tx.Target)
-		assert.Equal(t, "0", tx.Quantity)
+		assert.True(t, tx.Quantity.IsZero())
 		assert.NotNil(t, tx.Tags)
 		assert.Equal(t, "0", tx.DataSize)
 	})
 
 	t.Run("Create AR transfer transaction", func(t *testing.T) {
 		target := "test_address"
-		quantity := "1000000000000" // 1 AR in Winston
+		quantity, err := currency.ParseAR("1")
+		require.NoError(t, err)
 		tx := New(nil, target, quantity, nil)
 
 		assert.Equal(t, 2, tx.Format)
@@ -99,10 +101,26 @@ func TestNew(t *testing.T) {
 			{Name: "Content-Type", Value: "text/plain"},
 			{Name: "App-Name", Value: "Test-App"},
 		}
-		tx := New([]byte("test"), "", "0", tags)
+		tx := New([]byte("test"), "", currency.Zero, tags)
 
 		assert.NotNil(t, tx.Tags)
 		assert.Len(t, *tx.Tags, 2) // Should have 2 tags
 		// Note: New() converts tags to base64url format, so we can't directly compare
 	})
 }
+
+// TestMatchTags verifies transactions are matched on their decoded tags
+func TestMatchTags(t *testing.T) {
+	tx := New([]byte("image"), "", currency.Zero, &[]tag.Tag{{Name: "Content-Type", Value: "image/png"}})
+
+	ok, err := tx.MatchTags(tag.MustCompile(`Content-Type ^= "image/"`))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = tx.MatchTags(tag.MustCompile(`HAS App-Name`))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	tx.Tags = &[]tag.Tag{{Name: "!", Value: "!"}}
+	_, err = tx.MatchTags(tag.MustCompile(`HAS Type`))
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/currency"
 	"github.com/liteseed/goar/tag"
 )
 
@@ -61,18 +62,18 @@ type Node struct {
 // according to the version 2 format specification. It supports both data
 // transactions (storing data on Arweave) and transfer transactions (sending AR tokens).
 type Transaction struct {
-	Format    int        `json:"format"`    // Transaction format version (always 2 for this implementation)
-	ID        string     `json:"id"`        // Transaction ID (SHA256 hash of signature)
-	LastTx    string     `json:"last_tx"`   // Hash of the last transaction from this wallet
-	Owner     string     `json:"owner"`     // Base64url-encoded public key of the transaction owner
-	Tags      *[]tag.Tag `json:"tags"`      // Optional metadata tags
-	Target    string     `json:"target"`    // Target wallet address (for AR transfers)
-	Quantity  string     `json:"quantity"`  // Amount of AR to transfer in Winston units
-	Data      string     `json:"data"`      // Base64url-encoded transaction data
-	Reward    string     `json:"reward"`    // Transaction fee in Winston units
-	Signature string     `json:"signature"` // Base64url-encoded transaction signature
-	DataSize  string     `json:"data_size"` // Size of the data in bytes
-	DataRoot  string     `json:"data_root"` // Merkle root hash of the data chunks
+	Format    int              `json:"format"`    // Transaction format version (always 2 for this implementation)
+	ID        string           `json:"id"`        // Transaction ID (SHA256 hash of signature)
+	LastTx    string           `json:"last_tx"`   // Hash of the last transaction from this wallet
+	Owner     string           `json:"owner"`     // Base64url-encoded public key of the transaction owner
+	Tags      *[]tag.Tag       `json:"tags"`      // Optional metadata tags
+	Target    string           `json:"target"`    // Target wallet address (for AR transfers)
+	Quantity  currency.Winston `json:"quantity"`  // Amount of AR to transfer
+	Data      string           `json:"data"`      // Base64url-encoded transaction data
+	Reward    currency.Winston `json:"reward"`    // Transaction fee
+	Signature string           `json:"signature"` // Base64url-encoded transaction signature
+	DataSize  string           `json:"data_size"` // Size of the data in bytes
+	DataRoot  string           `json:"data_root"` // Merkle root hash of the data chunks
 
 	ChunkData *ChunkData `json:"-"` // Chunk data for large transactions (not serialized)
 }
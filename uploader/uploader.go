@@ -31,12 +31,15 @@ package uploader
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"math"
 	"math/rand"
 	"slices"
 	"time"
 
 	"github.com/liteseed/goar/client"
+	"github.com/liteseed/goar/internal/logging"
+	"github.com/liteseed/goar/metrics"
 	"github.com/liteseed/goar/transaction"
 )
 
@@ -64,9 +67,14 @@ var FATAL_CHUNK_UPLOAD_ERRORS = []string{
 // which chunks have been uploaded, error counts, and timing information.
 // It handles both simple uploads (small transactions) and chunked uploads
 // (large transactions with Merkle proofs).
+//
+// Posts, chunk uploads and retries are logged at debug level to Logger, and
+// retries are counted in Metrics as CHUNK_RETRIES.
 type TransactionUploader struct {
 	client             *client.Client           // HTTP client for communicating with Arweave nodes
 	transaction        *transaction.Transaction // The transaction being uploaded
+	Logger             *slog.Logger             // Optional logger for upload events (defaults to the client Logger, nil discards them)
+	Metrics            metrics.Recorder         // Optional recorder for upload events (defaults to the client Metrics, nil discards them)
 	ChunkIndex         int                      // Index of the next chunk to upload
 	TxPosted           bool                     // Whether the transaction header has been posted
 	Data               []byte                   // Raw transaction data (for chunk generation)
@@ -100,6 +108,8 @@ func New(c *client.Client, t *transaction.Transaction) (*TransactionUploader, er
 	return &TransactionUploader{
 		client:             c,
 		transaction:        t,
+		Logger:             c.Logger,
+		Metrics:            c.Metrics,
 		ChunkIndex:         0,
 		TxPosted:           false,
 		Data:               nil,
@@ -137,10 +147,12 @@ func (tu *TransactionUploader) PostTransaction() error {
 	if tu.TotalChunks <= MAX_CHUNKS_IN_BODY {
 		code, err := tu.client.SubmitTransaction(tu.transaction)
 		if err != nil {
+			tu.logger().Debug("transaction post failed", "id", tu.transaction.ID, "status", code, "error", err)
 			return err
 		}
 		tu.LastRequestTimeEnd = time.Now().UTC().UnixMilli()
 		tu.LastResponseStatus = code
+		tu.logger().Debug("transaction posted", "id", tu.transaction.ID, "status", code, "with_data", true)
 		if code >= 200 && code < 400 {
 			tu.TxPosted = true
 			tu.ChunkIndex = MAX_CHUNKS_IN_BODY
@@ -152,10 +164,12 @@ func (tu *TransactionUploader) PostTransaction() error {
 		t.Data = ""
 		code, err := tu.client.SubmitTransaction(t)
 		if err != nil {
+			tu.logger().Debug("transaction post failed", "id", t.ID, "status", code, "error", err)
 			return err
 		}
 		tu.LastRequestTimeEnd = time.Now().UTC().UnixMilli()
 		tu.LastResponseStatus = code
+		tu.logger().Debug("transaction posted", "id", t.ID, "status", code, "with_data", false)
 		if code >= 200 && code < 300 {
 			tu.TxPosted = true
 			return nil
@@ -207,6 +221,7 @@ func (tu *TransactionUploader) UploadChunk(chunkIndex int) error {
 	}
 
 	if tu.TotalErrors == 100 {
+		tu.logger().Debug("chunk upload abandoned", "id", tu.transaction.ID, "chunk", chunkIndex, "errors", tu.TotalErrors)
 		return fmt.Errorf("fatal: unable to complete upload: %d: %s", tu.LastResponseStatus, tu.LastResponseError)
 	}
 
@@ -217,6 +232,9 @@ func (tu *TransactionUploader) UploadChunk(chunkIndex int) error {
 
 	if delay > 0 {
 		delay = delay - delay*0.3*rand.Float64()
+		metrics.OrNop(tu.Metrics).Add(metrics.CHUNK_RETRIES, 1)
+		tu.logger().Debug("retrying chunk upload", "id", tu.transaction.ID, "chunk", chunkIndex, "errors", tu.TotalErrors,
+			"delay", time.Duration(delay)*time.Millisecond, "last_status", tu.LastResponseStatus, "last_error", tu.LastResponseError)
 		time.Sleep(time.Duration(delay) * time.Millisecond)
 	}
 
@@ -234,14 +252,21 @@ func (tu *TransactionUploader) UploadChunk(chunkIndex int) error {
 	tu.LastResponseStatus = code
 
 	if tu.LastResponseStatus == 200 {
+		tu.logger().Debug("chunk uploaded", "id", tu.transaction.ID, "chunk", chunkIndex)
 		tu.ChunkIndex++
 	} else {
 		if err != nil {
 			tu.LastResponseError = err.Error()
 		}
+		tu.logger().Debug("chunk upload failed", "id", tu.transaction.ID, "chunk", chunkIndex, "status", code, "error", err)
 		if slices.Contains(FATAL_CHUNK_UPLOAD_ERRORS, tu.LastResponseError) {
 			return fmt.Errorf("fatal: unable to complete upload: %d: %s", tu.LastResponseStatus, tu.LastResponseError)
 		}
 	}
 	return nil
 }
+
+// logger returns the Logger of the uploader, or a logger that discards records.
+func (tu *TransactionUploader) logger() *slog.Logger {
+	return logging.OrDiscard(tu.Logger)
+}
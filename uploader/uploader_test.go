@@ -2,9 +2,14 @@
 package uploader
 
 import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/liteseed/goar/client"
+	"github.com/liteseed/goar/currency"
 	"github.com/liteseed/goar/signer"
 	"github.com/liteseed/goar/transaction"
 	"github.com/stretchr/testify/assert"
@@ -16,7 +21,7 @@ func TestNew(t *testing.T) {
 	// Create a mock client and transaction
 	client := client.New("http://localhost:1984")
 	data := []byte("test data")
-	tx := transaction.New(data, "", "0", nil)
+	tx := transaction.New(data, "", currency.Zero, nil)
 
 	uploader, err := New(client, tx)
 	require.NoError(t, err)
@@ -36,7 +41,7 @@ func TestUploaderInitialization(t *testing.T) {
 
 	t.Run("Small transaction", func(t *testing.T) {
 		data := []byte("small data")
-		tx := transaction.New(data, "", "0", nil)
+		tx := transaction.New(data, "", currency.Zero, nil)
 
 		uploader, err := New(client, tx)
 		require.NoError(t, err)
@@ -45,7 +50,7 @@ func TestUploaderInitialization(t *testing.T) {
 	})
 
 	t.Run("Empty transaction", func(t *testing.T) {
-		tx := transaction.New(nil, "target", "1000", nil)
+		tx := transaction.New(nil, "target", currency.NewWinston(1000), nil)
 
 		uploader, err := New(client, tx)
 		require.NoError(t, err)
@@ -96,7 +101,7 @@ func TestConstants(t *testing.T) {
 func TestUploaderFields(t *testing.T) {
 	client := client.New("http://localhost:1984")
 	data := []byte("test data for uploader")
-	tx := transaction.New(data, "", "0", nil)
+	tx := transaction.New(data, "", currency.Zero, nil)
 
 	uploader, err := New(client, tx)
 	require.NoError(t, err)
@@ -127,10 +132,10 @@ func createMockSignedTransaction(t *testing.T) *transaction.Transaction {
 	require.NoError(t, err)
 
 	data := []byte("test transaction data")
-	tx := transaction.New(data, "", "0", nil)
+	tx := transaction.New(data, "", currency.Zero, nil)
 	tx.Owner = s.Owner()
 	tx.LastTx = "test_anchor"
-	tx.Reward = "1000"
+	tx.Reward = currency.NewWinston(1000)
 
 	err = tx.Sign(s)
 	require.NoError(t, err)
@@ -173,3 +178,23 @@ func TestPostTransactionSmall(t *testing.T) {
 	assert.NotPanics(t, func() { uploader.PostTransaction() })
 }
 */
+
+// TestLogger verifies the uploader logs to the client logger by default
+func TestLogger(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	c := client.New(srv.URL)
+	c.Logger = slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	tx := createMockSignedTransaction(t)
+
+	uploader, err := New(c, tx)
+	require.NoError(t, err)
+	assert.Equal(t, c.Logger, uploader.Logger)
+
+	require.NoError(t, uploader.PostTransaction())
+	assert.True(t, uploader.TxPosted)
+	assert.Contains(t, buf.String(), `msg="transaction posted" id=`+tx.ID+" status=200 with_data=true")
+	assert.Contains(t, buf.String(), `msg="http request" method=POST`)
+}
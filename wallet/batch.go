@@ -0,0 +1,308 @@
+package wallet
+
+import (
+	"context"
+	"encoding/base64"
+	"runtime"
+	"sync"
+
+	"github.com/liteseed/goar/client"
+	"github.com/liteseed/goar/pricing"
+	"github.com/liteseed/goar/transaction"
+)
+
+// ANCHOR_EXPIRY_MARGIN is the number of blocks before the anchor expires at
+// which a Batch treats it as stale, leaving time for posted transactions to
+// propagate.
+const ANCHOR_EXPIRY_MARGIN = 5
+
+// Batch signs and sends many transactions from one wallet.
+//
+// A batch fetches the anchor and a price snapshot once and reuses them for
+// every transaction, so signing N transactions costs a constant number of
+// requests instead of 2N. Transactions are signed in parallel.
+//
+// An anchor is only accepted for MAX_ANCHOR_DEPTH blocks. Before posting, the
+// batch checks the network height and, if the anchor is about to expire,
+// fetches a new one and re-signs every transaction that has not been posted.
+//
+// A Batch is safe for concurrent use.
+//
+// Example:
+//
+//	b, err := w.NewBatch(ctx)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, data := range files {
+//		b.Add(w.CreateTransaction(data, "", currency.Zero, nil))
+//	}
+//	if err = b.Sign(ctx); err != nil {
+//		log.Fatal(err)
+//	}
+//	if err = b.Send(ctx); err != nil {
+//		log.Fatal(err)
+//	}
+type Batch struct {
+	Parallelism int // Number of transactions signed concurrently (defaults to the number of CPUs)
+
+	wallet    *Wallet
+	estimator *pricing.Estimator
+
+	mu           sync.Mutex
+	anchor       string                            // Current anchor
+	anchorHeight int64                             // Height of the anchor block
+	txs          []*transaction.Transaction        // Transactions in the order they were added
+	posted       map[*transaction.Transaction]bool // Transactions already sent
+}
+
+// NewBatch creates a batch, fetching the anchor and price snapshot once.
+//
+// If the wallet has an Estimator it is used for pricing, otherwise a price
+// snapshot is fetched and used for the lifetime of the batch.
+//
+// Parameters:
+//   - ctx: Context for the network requests
+//
+// Returns the Batch, or an error if the anchor or prices cannot be fetched.
+func (w *Wallet) NewBatch(ctx context.Context) (*Batch, error) {
+	estimator := w.Estimator
+	if estimator == nil {
+		s, err := pricing.Fetch(ctx, w.Client, nil)
+		if err != nil {
+			return nil, err
+		}
+		estimator = pricing.NewEstimator(nil, s)
+	}
+
+	b := &Batch{
+		Parallelism: runtime.NumCPU(),
+		wallet:      w,
+		estimator:   estimator,
+		posted:      map[*transaction.Transaction]bool{},
+	}
+	if err := b.fetchAnchor(ctx); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Add appends transactions to the batch.
+//
+// The transactions are signed by the next call to Sign or Send.
+func (b *Batch) Add(txs ...*transaction.Transaction) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.txs = append(b.txs, txs...)
+}
+
+// Transactions returns the transactions of the batch in the order they were added.
+func (b *Batch) Transactions() []*transaction.Transaction {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*transaction.Transaction(nil), b.txs...)
+}
+
+// Anchor returns the current anchor and the height of its block.
+func (b *Batch) Anchor() (string, int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.anchor, b.anchorHeight
+}
+
+// Sign signs every unsigned transaction of the batch in parallel.
+//
+// Transactions are signed with the batch anchor and a reward estimated from
+// the batch price snapshot.
+//
+// Parameters:
+//   - ctx: Context used to stop signing early
+//
+// Returns the first signing error, or nil if all transactions are signed.
+func (b *Batch) Sign(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var pending []*transaction.Transaction
+	for _, tx := range b.txs {
+		if tx.Signature == "" {
+			pending = append(pending, tx)
+		}
+	}
+	return b.sign(ctx, pending)
+}
+
+// Expired reports whether the batch anchor is stale.
+//
+// Parameters:
+//   - ctx: Context for the network request
+//
+// Returns true if the network height is within ANCHOR_EXPIRY_MARGIN blocks of
+// the anchor expiry, or an error if the height cannot be fetched.
+func (b *Batch) Expired(ctx context.Context) (bool, error) {
+	info, err := b.wallet.Client.GetNetworkInfoContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return info.Height+ANCHOR_EXPIRY_MARGIN > b.anchorHeight+client.MAX_ANCHOR_DEPTH, nil
+}
+
+// Reanchor fetches a new anchor and re-signs every transaction that has not
+// been posted yet.
+//
+// Parameters:
+//   - ctx: Context for the network requests
+//
+// Returns the number of re-signed transactions, or an error.
+func (b *Batch) Reanchor(ctx context.Context) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.fetchAnchorLocked(ctx); err != nil {
+		return 0, err
+	}
+	var unposted []*transaction.Transaction
+	for _, tx := range b.txs {
+		if !b.posted[tx] {
+			unposted = append(unposted, tx)
+		}
+	}
+	b.wallet.logger().DebugContext(ctx, "batch reanchored", "anchor", b.anchor, "height", b.anchorHeight, "resigned", len(unposted))
+	return len(unposted), b.sign(ctx, unposted)
+}
+
+// Send signs any unsigned transactions and posts every transaction that has
+// not been posted yet, in the order they were added.
+//
+// If the anchor is stale, the unposted transactions are re-anchored first.
+// Their IDs change when they are re-signed.
+//
+// Parameters:
+//   - ctx: Context used to stop sending early
+//
+// Returns the first error. Transactions posted before the error are not
+// posted again by a later call.
+func (b *Batch) Send(ctx context.Context) error {
+	expired, err := b.Expired(ctx)
+	if err != nil {
+		return err
+	}
+	if expired {
+		if _, err = b.Reanchor(ctx); err != nil {
+			return err
+		}
+	}
+	if err = b.Sign(ctx); err != nil {
+		return err
+	}
+
+	for _, tx := range b.Transactions() {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		b.mu.Lock()
+		posted := b.posted[tx]
+		b.mu.Unlock()
+		if posted {
+			continue
+		}
+		if err = b.wallet.SendTransaction(tx); err != nil {
+			return err
+		}
+		b.mu.Lock()
+		b.posted[tx] = true
+		b.mu.Unlock()
+	}
+	return nil
+}
+
+// Posted reports whether a transaction of the batch has been posted.
+func (b *Batch) Posted(tx *transaction.Transaction) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.posted[tx]
+}
+
+// fetchAnchor fetches a new anchor and the height of its block.
+func (b *Batch) fetchAnchor(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.fetchAnchorLocked(ctx)
+}
+
+func (b *Batch) fetchAnchorLocked(ctx context.Context) error {
+	c := b.wallet.Client
+	anchor, err := c.GetTransactionAnchorContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The anchor is normally a block hash. If its block cannot be found,
+	// assume it is the current block.
+	var height int64
+	if block, err := c.GetBlockByIDContext(ctx, anchor); err == nil {
+		height = int64(block.Height)
+	} else {
+		info, err := c.GetNetworkInfoContext(ctx)
+		if err != nil {
+			return err
+		}
+		height = info.Height
+	}
+	b.anchor, b.anchorHeight = anchor, height
+	return nil
+}
+
+// sign signs transactions in parallel with the current anchor. b.mu must be held.
+func (b *Batch) sign(ctx context.Context, txs []*transaction.Transaction) error {
+	parallelism := b.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	jobs := make(chan *transaction.Transaction)
+	errs := make(chan error, len(txs))
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for tx := range jobs {
+				errs <- b.signOne(ctx, tx)
+			}
+		}()
+	}
+
+	var err error
+	for _, tx := range txs {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		jobs <- tx
+	}
+	close(jobs)
+	wg.Wait()
+	close(errs)
+
+	for e := range errs {
+		if e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// signOne sets the owner, anchor and reward of a transaction and signs it.
+func (b *Batch) signOne(ctx context.Context, tx *transaction.Transaction) error {
+	size := base64.RawURLEncoding.DecodedLen(len(tx.Data))
+	reward, err := b.estimator.Estimate(ctx, size, tx.Target)
+	if err != nil {
+		return err
+	}
+	tx.Owner = b.wallet.Signer.Owner()
+	tx.LastTx = b.anchor
+	tx.Reward = reward
+	return b.wallet.sign(tx)
+}
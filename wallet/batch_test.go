@@ -0,0 +1,174 @@
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/liteseed/goar/currency"
+	"github.com/liteseed/goar/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	batchAnchorA = "YW5jaG9yLWEtYW5jaG9yLWEtYW5jaG9yLWEtYW5jaG9yLWEt"
+	batchAnchorB = "YW5jaG9yLWItYW5jaG9yLWItYW5jaG9yLWItYW5jaG9yLWIt"
+)
+
+// batchNode serves the endpoints used by Batch and counts requests per route.
+type batchNode struct {
+	mu       sync.Mutex
+	anchor   string
+	height   int64
+	blocks   map[string]int64
+	requests map[string]int
+	posted   []*transaction.Transaction
+}
+
+func (n *batchNode) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	route := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")[0]
+	n.requests[route]++
+	switch route {
+	case "tx_anchor":
+		fmt.Fprint(rw, n.anchor)
+	case "info":
+		fmt.Fprintf(rw, `{"height":%d}`, n.height)
+	case "price":
+		fmt.Fprint(rw, "100")
+	case "block":
+		id := strings.TrimPrefix(r.URL.Path, "/block/hash/")
+		h, ok := n.blocks[id]
+		if !ok {
+			http.NotFound(rw, r)
+			return
+		}
+		fmt.Fprintf(rw, `{"height":%d,"weave_size":"0","block_size":"0"}`, h)
+	case "tx":
+		tx := &transaction.Transaction{}
+		if err := json.NewDecoder(r.Body).Decode(tx); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		n.posted = append(n.posted, tx)
+	default:
+		http.NotFound(rw, r)
+	}
+}
+
+func newBatchWallet(t *testing.T, n *batchNode) *Wallet {
+	w, err := FromPath("../test/signer.json", "")
+	require.NoError(t, err)
+	s := httptest.NewServer(n)
+	t.Cleanup(s.Close)
+	w.Client.Gateway = s.URL
+	return w
+}
+
+func TestBatch(t *testing.T) {
+	n := &batchNode{
+		anchor:   batchAnchorA,
+		height:   100,
+		blocks:   map[string]int64{batchAnchorA: 100, batchAnchorB: 150},
+		requests: map[string]int{},
+	}
+	w := newBatchWallet(t, n)
+	ctx := context.Background()
+
+	b, err := w.NewBatch(ctx)
+	require.NoError(t, err)
+	anchor, height := b.Anchor()
+	assert.Equal(t, batchAnchorA, anchor)
+	assert.Equal(t, int64(100), height)
+
+	for i := 0; i < 20; i++ {
+		b.Add(w.CreateTransaction([]byte(fmt.Sprintf("item %d", i)), "", currency.Zero, nil))
+		if i == 9 {
+			// Post the first half while the anchor is fresh
+			require.NoError(t, b.Send(ctx))
+		}
+	}
+	require.NoError(t, b.Sign(ctx))
+
+	for i, tx := range b.Transactions() {
+		assert.Equal(t, batchAnchorA, tx.LastTx)
+		assert.Equal(t, "100", tx.Reward.String())
+		assert.NoError(t, tx.Verify())
+		assert.Equal(t, i < 10, b.Posted(tx))
+	}
+	n.mu.Lock()
+	assert.Equal(t, 1, n.requests["tx_anchor"], "the anchor is fetched once per batch")
+	prices := n.requests["price"]
+	n.mu.Unlock()
+
+	// The anchor expires before the rest is sent
+	n.mu.Lock()
+	n.anchor = batchAnchorB
+	n.height = 100 + 50 - ANCHOR_EXPIRY_MARGIN + 1
+	n.mu.Unlock()
+
+	expired, err := b.Expired(ctx)
+	require.NoError(t, err)
+	assert.True(t, expired)
+
+	oldIDs := map[string]bool{}
+	for _, tx := range b.Transactions()[10:] {
+		oldIDs[tx.ID] = true
+	}
+
+	require.NoError(t, b.Send(ctx))
+	anchor, height = b.Anchor()
+	assert.Equal(t, batchAnchorB, anchor)
+	assert.Equal(t, int64(150), height)
+
+	for i, tx := range b.Transactions() {
+		if i < 10 {
+			assert.Equal(t, batchAnchorA, tx.LastTx, "posted transactions keep their anchor")
+			continue
+		}
+		assert.Equal(t, batchAnchorB, tx.LastTx)
+		assert.False(t, oldIDs[tx.ID], "re-anchored transactions are re-signed")
+		assert.NoError(t, tx.Verify())
+		assert.True(t, b.Posted(tx))
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	assert.Len(t, n.posted, 20)
+	assert.Equal(t, prices, n.requests["price"], "prices are not fetched again")
+	for _, tx := range n.posted {
+		assert.NoError(t, tx.Verify())
+	}
+}
+
+func TestBatchSendTwice(t *testing.T) {
+	n := &batchNode{
+		anchor:   batchAnchorA,
+		height:   100,
+		blocks:   map[string]int64{batchAnchorA: 100},
+		requests: map[string]int{},
+	}
+	w := newBatchWallet(t, n)
+	ctx := context.Background()
+
+	b, err := w.NewBatch(ctx)
+	require.NoError(t, err)
+	b.Add(w.CreateTransaction([]byte("a"), "", currency.Zero, nil))
+	require.NoError(t, b.Send(ctx))
+	require.NoError(t, b.Send(ctx))
+
+	b.Add(w.CreateTransaction([]byte("b"), "", currency.Zero, nil))
+	require.NoError(t, b.Send(ctx))
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	assert.Len(t, n.posted, 2)
+}
@@ -0,0 +1,99 @@
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/liteseed/goar/client"
+	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/transaction"
+)
+
+// PrepareUnsigned fills in the network fields of a transaction and exports it
+// for offline signing.
+//
+// This is the first step of the air-gapped workflow and runs on an online
+// host that only knows the public owner of the signing key:
+// 1. PrepareUnsigned sets the owner, anchor and reward and exports the transaction without its data
+// 2. The offline host calls transaction.SignUnsigned, which shows the owner, target, quantity, reward and data root for confirmation and returns the signed header as JSON
+// 3. The online host calls ImportSigned with the original data and posts the result
+//
+// The anchor expires after about 50 blocks, so the signed transaction must be
+// posted within that window.
+//
+// Parameters:
+//   - ctx: Context for the network requests
+//   - c: Client used to fetch the anchor and price
+//   - owner: The base64url-encoded public key of the signing wallet
+//   - tx: The transaction to prepare (created with transaction.New)
+//
+// Returns the portable unsigned transaction JSON, or an error.
+//
+// Example:
+//
+//	tx := transaction.New(data, "", currency.Zero, nil)
+//	unsigned, err := wallet.PrepareUnsigned(ctx, c, owner, tx)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	os.WriteFile("unsigned.json", unsigned, 0o644)
+func PrepareUnsigned(ctx context.Context, c *client.Client, owner string, tx *transaction.Transaction) ([]byte, error) {
+	if owner == "" {
+		return nil, errors.New("owner is empty")
+	}
+	if _, err := crypto.Base64URLDecode(owner); err != nil {
+		return nil, fmt.Errorf("invalid owner: %w", err)
+	}
+	if err := prepareTransaction(ctx, c, nil, owner, tx); err != nil {
+		return nil, err
+	}
+	return tx.ExportUnsigned()
+}
+
+// ImportSigned imports a transaction signed offline and reattaches its data.
+//
+// The signature is verified, and the data must match the data root that was
+// signed.
+//
+// Parameters:
+//   - b: The signed transaction JSON produced on the offline host
+//   - data: The original transaction data (can be nil for transactions without data)
+//
+// Returns the complete signed transaction ready to be posted, or an error if
+// the signature is invalid or the data does not match.
+//
+// Example:
+//
+//	tx, err := wallet.ImportSigned(signed, data)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	w := &wallet.Wallet{Client: c}
+//	err = w.SendTransaction(tx)
+func ImportSigned(b []byte, data []byte) (*transaction.Transaction, error) {
+	tx := &transaction.Transaction{}
+	if err := json.Unmarshal(b, tx); err != nil {
+		return nil, err
+	}
+	if tx.ID == "" || tx.Signature == "" {
+		return nil, errors.New("transaction not signed")
+	}
+	if err := tx.Verify(); err != nil {
+		return nil, err
+	}
+
+	dataRoot, dataSize := tx.DataRoot, tx.DataSize
+	if err := tx.PrepareChunks(data); err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		tx.DataSize = "0"
+	}
+	if tx.DataRoot != dataRoot || tx.DataSize != dataSize {
+		return nil, fmt.Errorf("data does not match the signed data root %q and size %s", dataRoot, dataSize)
+	}
+	tx.Data = crypto.Base64URLEncode(data)
+	return tx, nil
+}
@@ -0,0 +1,81 @@
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/liteseed/goar/currency"
+	"github.com/liteseed/goar/signer"
+	"github.com/liteseed/goar/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOfflineSigning(t *testing.T) {
+	s, err := signer.FromPath("../test/signer.json")
+	require.NoError(t, err)
+
+	// The online host only has a client and the owner
+	c := newBatchWallet(t, &batchNode{anchor: batchAnchorA, requests: map[string]int{}}).Client
+	data := make([]byte, 2*transaction.MAX_CHUNK_SIZE+100)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	unsigned, err := PrepareUnsigned(context.Background(), c, s.Owner(), transaction.New(data, "", currency.Zero, nil))
+	require.NoError(t, err)
+
+	// Air-gapped host
+	tx, err := transaction.ImportUnsigned(unsigned)
+	require.NoError(t, err)
+	assert.Equal(t, batchAnchorA, tx.LastTx)
+	assert.Equal(t, "100", tx.Reward.String())
+	require.NoError(t, tx.Sign(s))
+	signed, err := json.Marshal(tx)
+	require.NoError(t, err)
+
+	// Online host
+	imported, err := ImportSigned(signed, data)
+	require.NoError(t, err)
+	assert.Equal(t, tx.ID, imported.ID, "the ID is stable across the round trip")
+	assert.NoError(t, imported.Verify())
+	assert.NotEmpty(t, imported.Data)
+
+	_, err = ImportSigned(signed, data[1:])
+	assert.Error(t, err, "data must match the signed data root")
+
+	imported.Reward = currency.NewWinston(1)
+	tampered, err := json.Marshal(imported)
+	require.NoError(t, err)
+	_, err = ImportSigned(tampered, data)
+	assert.Error(t, err, "the signature must cover the imported fields")
+
+	unsignedJSON, err := json.Marshal(transaction.New(nil, "", currency.Zero, nil))
+	require.NoError(t, err)
+	_, err = ImportSigned(unsignedJSON, nil)
+	assert.Error(t, err)
+}
+
+func TestOfflineSigningEmptyData(t *testing.T) {
+	s, err := signer.FromPath("../test/signer.json")
+	require.NoError(t, err)
+	c := newBatchWallet(t, &batchNode{anchor: batchAnchorA, requests: map[string]int{}}).Client
+
+	unsigned, err := PrepareUnsigned(context.Background(), c, s.Owner(), transaction.New(nil, transferTarget, currency.NewWinston(5), nil))
+	require.NoError(t, err)
+	tx, err := transaction.ImportUnsigned(unsigned)
+	require.NoError(t, err)
+	require.NoError(t, tx.Sign(s))
+	signed, err := json.Marshal(tx)
+	require.NoError(t, err)
+
+	imported, err := ImportSigned(signed, nil)
+	require.NoError(t, err)
+	assert.Equal(t, tx.ID, imported.ID)
+	assert.Equal(t, transferTarget, imported.Target)
+	assert.Equal(t, "5", imported.Quantity.String())
+
+	_, err = PrepareUnsigned(context.Background(), c, "", transaction.New(nil, "", currency.Zero, nil))
+	assert.Error(t, err)
+}
@@ -0,0 +1,111 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/liteseed/goar/client"
+	"github.com/liteseed/goar/crypto"
+	"github.com/liteseed/goar/currency"
+	"github.com/liteseed/goar/transaction"
+)
+
+// ErrInsufficientBalance is returned by Transfer when the wallet balance does
+// not cover the amount and the reward.
+var ErrInsufficientBalance = errors.New("insufficient balance")
+
+// PendingTransfer is a submitted AR transfer.
+//
+// It records what was sent and can be used to track the transfer until it is
+// confirmed.
+type PendingTransfer struct {
+	ID          string                   // ID of the transfer transaction
+	Target      string                   // Address receiving the amount
+	Amount      currency.Winston         // Amount sent to the target
+	Reward      currency.Winston         // Fee paid to the network
+	Transaction *transaction.Transaction // The signed transfer transaction
+
+	client *client.Client
+}
+
+// Status retrieves the current confirmation status of the transfer.
+//
+// Parameters:
+//   - ctx: Context for the request
+//
+// Returns the TransactionStatus, or an error if the transfer is not known to
+// the gateway yet.
+func (p *PendingTransfer) Status(ctx context.Context) (*client.TransactionStatus, error) {
+	return p.client.GetTransactionStatusContext(ctx, p.ID)
+}
+
+// Transfer sends AR from this wallet to another address.
+//
+// This method:
+// 1. Validates the target address
+// 2. Prices the transfer with the target included, so the new wallet fee is paid for targets that do not exist yet
+// 3. Checks that the wallet balance covers the amount plus the reward
+// 4. Signs and posts the transaction
+//
+// Parameters:
+//   - ctx: Context for all network requests
+//   - target: The address receiving the AR
+//   - amount: The amount to send, must be positive
+//
+// Returns a PendingTransfer for tracking, or an error if validation fails,
+// the balance is insufficient (wrapping ErrInsufficientBalance) or the
+// transaction cannot be posted.
+//
+// Example:
+//
+//	amount, err := currency.ParseAR("1.5")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	transfer, err := w.Transfer(ctx, "F7fmxSBJx5RlIRrt825iIEAL110cKP2Bf8tYd0Q1STU", amount)
+//	if errors.Is(err, wallet.ErrInsufficientBalance) {
+//		log.Fatal("top up the wallet first")
+//	}
+//	fmt.Printf("Transfer %s sent, fee %s AR\n", transfer.ID, transfer.Reward.AR())
+func (w *Wallet) Transfer(ctx context.Context, target string, amount currency.Winston) (*PendingTransfer, error) {
+	if err := crypto.ValidateAddress(target); err != nil {
+		return nil, err
+	}
+	if amount.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid transfer amount: %s", amount)
+	}
+
+	tx := w.CreateTransaction(nil, target, amount, nil)
+	if err := w.prepare(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	balance, err := w.Client.GetWalletBalanceContext(ctx, w.Signer.Address)
+	if err != nil {
+		return nil, err
+	}
+	total := amount.Add(tx.Reward)
+	if balance.Cmp(total) < 0 {
+		return nil, fmt.Errorf("%w: balance %s AR, required %s AR (amount %s AR + reward %s AR)",
+			ErrInsufficientBalance, balance.AR(), total.AR(), amount.AR(), tx.Reward.AR())
+	}
+
+	if err = w.sign(tx); err != nil {
+		return nil, err
+	}
+	if _, err = w.Client.SubmitTransactionContext(ctx, tx); err != nil {
+		w.logger().DebugContext(ctx, "transfer send failed", "id", tx.ID, "error", err)
+		return nil, err
+	}
+	w.logger().DebugContext(ctx, "transfer sent", "id", tx.ID, "target", target, "amount", amount.String(), "reward", tx.Reward.String())
+
+	return &PendingTransfer{
+		ID:          tx.ID,
+		Target:      target,
+		Amount:      amount,
+		Reward:      tx.Reward,
+		Transaction: tx,
+		client:      w.Client,
+	}, nil
+}
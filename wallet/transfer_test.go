@@ -0,0 +1,185 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/liteseed/goar/currency"
+	"github.com/liteseed/goar/metrics"
+	"github.com/liteseed/goar/pricing"
+	"github.com/liteseed/goar/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const transferTarget = "F7fmxSBJx5RlIRrt825iIEAL110cKP2Bf8tYd0Q1STU"
+
+// newTransferServer serves the endpoints used by Transfer and records posted transactions.
+func newTransferServer(t *testing.T, w *Wallet, balance string, posted *[]*transaction.Transaction) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tx_anchor", func(rw http.ResponseWriter, r *http.Request) {
+		_, _ = rw.Write([]byte("aGVsbG8gd29ybGQgYW5jaG9yIGhlbGxvIHdvcmxkIGFuY2hvcg"))
+	})
+	mux.HandleFunc("/price/0", func(rw http.ResponseWriter, r *http.Request) {
+		_, _ = rw.Write([]byte("100"))
+	})
+	mux.HandleFunc("/price/0/"+transferTarget, func(rw http.ResponseWriter, r *http.Request) {
+		_, _ = rw.Write([]byte("500"))
+	})
+	mux.HandleFunc("/wallet/"+w.Signer.Address+"/balance", func(rw http.ResponseWriter, r *http.Request) {
+		_, _ = rw.Write([]byte(balance))
+	})
+	mux.HandleFunc("/tx", func(rw http.ResponseWriter, r *http.Request) {
+		tx := &transaction.Transaction{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(tx))
+		*posted = append(*posted, tx)
+	})
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestTransfer(t *testing.T) {
+	w, err := FromPath("../test/signer.json", "")
+	require.NoError(t, err)
+
+	t.Run("Success", func(t *testing.T) {
+		var posted []*transaction.Transaction
+		w.Client.Gateway = newTransferServer(t, w, "1500", &posted).URL
+
+		p, err := w.Transfer(context.Background(), transferTarget, currency.NewWinston(1000))
+		require.NoError(t, err)
+		assert.Equal(t, transferTarget, p.Target)
+		assert.Equal(t, "1000", p.Amount.String())
+		assert.Equal(t, "500", p.Reward.String(), "reward must be priced with the target")
+
+		require.Len(t, posted, 1)
+		assert.Equal(t, p.ID, posted[0].ID)
+		assert.Equal(t, "1000", posted[0].Quantity.String())
+		assert.Equal(t, "500", posted[0].Reward.String())
+		assert.NoError(t, posted[0].Verify())
+	})
+
+	t.Run("Insufficient balance", func(t *testing.T) {
+		var posted []*transaction.Transaction
+		w.Client.Gateway = newTransferServer(t, w, "1499", &posted).URL
+
+		_, err := w.Transfer(context.Background(), transferTarget, currency.NewWinston(1000))
+		assert.True(t, errors.Is(err, ErrInsufficientBalance))
+		assert.Empty(t, posted)
+	})
+
+	t.Run("Invalid target", func(t *testing.T) {
+		_, err := w.Transfer(context.Background(), "not-an-address", currency.NewWinston(1000))
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid amount", func(t *testing.T) {
+		_, err := w.Transfer(context.Background(), transferTarget, currency.Zero)
+		assert.Error(t, err)
+	})
+
+	t.Run("Cancelled context", func(t *testing.T) {
+		var posted []*transaction.Transaction
+		w.Client.Gateway = newTransferServer(t, w, "1500", &posted).URL
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := w.Transfer(ctx, transferTarget, currency.NewWinston(1000))
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, posted)
+	})
+}
+
+func TestSignTransactionEstimator(t *testing.T) {
+	w, err := FromPath("../test/signer.json", "")
+	require.NoError(t, err)
+
+	var posted []*transaction.Transaction
+	w.Client.Gateway = newTransferServer(t, w, "0", &posted).URL
+	w.Estimator = pricing.NewEstimator(nil, &pricing.Snapshot{
+		Points: []pricing.Point{{Size: 0, Price: currency.NewWinston(42)}},
+	})
+
+	tx, err := w.SignTransaction(transaction.New(nil, "", currency.Zero, nil))
+	require.NoError(t, err)
+	assert.Equal(t, "42", tx.Reward.String())
+	assert.NoError(t, tx.Verify())
+}
+
+// TestSignTransactionPriceSize verifies the reward is priced for the raw data size
+func TestSignTransactionPriceSize(t *testing.T) {
+	w, err := FromPath("../test/signer.json", "")
+	require.NoError(t, err)
+
+	var priced []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tx_anchor", func(rw http.ResponseWriter, r *http.Request) {
+		_, _ = rw.Write([]byte("aGVsbG8gd29ybGQgYW5jaG9yIGhlbGxvIHdvcmxkIGFuY2hvcg"))
+	})
+	mux.HandleFunc("/price/", func(rw http.ResponseWriter, r *http.Request) {
+		priced = append(priced, r.URL.Path)
+		_, _ = rw.Write([]byte("100"))
+	})
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	w.Client.Gateway = s.URL
+
+	// 1000 bytes are 1334 base64url characters
+	_, err = w.SignTransaction(transaction.New(make([]byte, 1000), "", currency.Zero, nil))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/price/1000"}, priced)
+}
+
+// TestLogger verifies wallet events are logged at debug level
+func TestLogger(t *testing.T) {
+	w, err := FromPath("../test/signer.json", "")
+	require.NoError(t, err)
+	var posted []*transaction.Transaction
+	w.Client.Gateway = newTransferServer(t, w, "1500", &posted).URL
+
+	var buf bytes.Buffer
+	w.Logger = slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	p, err := w.Transfer(context.Background(), transferTarget, currency.NewWinston(1000))
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `msg="transfer sent" id=`+p.ID+" target="+transferTarget+" amount=1000 reward=500")
+
+	tx, err := w.SignTransaction(w.CreateTransaction(nil, "", currency.Zero, nil))
+	require.NoError(t, err)
+	require.NoError(t, w.SendTransaction(tx))
+	assert.Contains(t, buf.String(), `msg="transaction signed" id=`+tx.ID)
+	assert.Contains(t, buf.String(), `msg="transaction posted" id=`+tx.ID)
+	assert.Contains(t, buf.String(), `msg="transaction sent" id=`+tx.ID+" status=200")
+
+	// HTTP calls are only logged by the client logger
+	assert.NotContains(t, buf.String(), "http request")
+}
+
+// TestMetrics verifies signing time is recorded by the wallet and HTTP calls by the client
+func TestMetrics(t *testing.T) {
+	w, err := FromPath("../test/signer.json", "")
+	require.NoError(t, err)
+	var posted []*transaction.Transaction
+	w.Client.Gateway = newTransferServer(t, w, "1500", &posted).URL
+
+	m := metrics.NewMemory()
+	w.Metrics = m
+	_, err = w.Transfer(context.Background(), transferTarget, currency.NewWinston(1000))
+	require.NoError(t, err)
+	_, err = w.SignTransaction(w.CreateTransaction(nil, "", currency.Zero, nil))
+	require.NoError(t, err)
+	assert.Len(t, m.Observations(metrics.SIGN_DURATION, metrics.Label{Name: "kind", Value: "transaction"}), 2)
+	assert.Equal(t, []string{`goar_sign_duration_seconds{kind="transaction"}`}, m.Keys())
+
+	w.Client.Metrics = m
+	require.NoError(t, w.SendTransaction(posted[0]))
+	assert.Contains(t, m.Keys(), `goar_uploaded_bytes_total{gateway="`+strings.TrimPrefix(w.Client.Gateway, "http://")+`",route="tx"}`)
+}
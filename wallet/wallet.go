@@ -13,7 +13,7 @@
 //	}
 //
 //	// Create and send a transaction
-//	tx := wallet.CreateTransaction([]byte("Hello Arweave!"), "", "0", nil)
+//	tx := wallet.CreateTransaction([]byte("Hello Arweave!"), "", currency.Zero, nil)
 //	signedTx, err := wallet.SignTransaction(tx)
 //	if err != nil {
 //		log.Fatal(err)
@@ -25,10 +25,18 @@
 package wallet
 
 import (
+	"context"
+	"encoding/base64"
 	"errors"
+	"log/slog"
 	"os"
+	"time"
 
 	"github.com/liteseed/goar/client"
+	"github.com/liteseed/goar/currency"
+	"github.com/liteseed/goar/internal/logging"
+	"github.com/liteseed/goar/metrics"
+	"github.com/liteseed/goar/pricing"
 	"github.com/liteseed/goar/signer"
 	"github.com/liteseed/goar/tag"
 	"github.com/liteseed/goar/transaction"
@@ -43,9 +51,17 @@ import (
 // and a client for communicating with Arweave nodes. It provides a high-level
 // interface for common Arweave operations like creating transactions, data items,
 // and bundles.
+//
+// Signed and sent transactions are logged at debug level to Logger, and
+// signing time is recorded to Metrics as SIGN_DURATION. HTTP calls are logged
+// and recorded by the client, so set Client.Logger and Client.Metrics as well
+// to see them.
 type Wallet struct {
-	Client *client.Client // HTTP client for communicating with Arweave nodes
-	Signer *signer.Signer // Cryptographic signer for transaction signing
+	Client    *client.Client     // HTTP client for communicating with Arweave nodes
+	Signer    *signer.Signer     // Cryptographic signer for transaction signing
+	Estimator *pricing.Estimator // Optional fee estimator used instead of querying /price for every transaction
+	Logger    *slog.Logger       // Optional logger for wallet events (nil discards them)
+	Metrics   metrics.Recorder   // Optional recorder for signing time (nil discards it)
 }
 
 // New creates a new wallet with a randomly generated private key.
@@ -106,6 +122,66 @@ func FromPath(path string, gateway string) (*Wallet, error) {
 	return FromJWK(b, gateway)
 }
 
+// FromEncryptedPath creates a wallet from an encrypted keystore file.
+//
+// The keystore is decrypted with signer.FromEncryptedPath, so the private key
+// never has to be stored on disk in the clear.
+//
+// Parameters:
+//   - path: The file system path to the keystore written by signer.Export
+//   - passphrase: The passphrase the keystore was encrypted with
+//   - gateway: The URL of the Arweave gateway to use
+//
+// Returns a Wallet instance loaded with the decrypted key, or an error if the
+// file cannot be read, the keystore is invalid or the passphrase is wrong.
+//
+// Example:
+//
+//	wallet, err := FromEncryptedPath("./wallet.keystore.json", passphrase, "https://arweave.net")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func FromEncryptedPath(path string, passphrase string, gateway string) (*Wallet, error) {
+	s, err := signer.FromEncryptedPath(path, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return &Wallet{
+		Client: client.New(gateway),
+		Signer: s,
+	}, nil
+}
+
+// FromMnemonic creates a wallet from a BIP-39 mnemonic.
+//
+// The key is derived deterministically with signer.FromMnemonic, so the same
+// words always restore the same wallet. Phrases from JavaScript wallets may
+// restore a different address.
+//
+// Parameters:
+//   - words: The mnemonic created by signer.NewMnemonic
+//   - gateway: The URL of the Arweave gateway to use
+//
+// Returns a Wallet instance with the derived key, or an error if the mnemonic
+// is invalid.
+//
+// Example:
+//
+//	wallet, err := FromMnemonic(words, "https://arweave.net")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func FromMnemonic(words string, gateway string) (*Wallet, error) {
+	s, err := signer.FromMnemonic(words)
+	if err != nil {
+		return nil, err
+	}
+	return &Wallet{
+		Client: client.New(gateway),
+		Signer: s,
+	}, nil
+}
+
 // FromJWK creates a wallet from JWK data in memory.
 //
 // This function creates a wallet from JSON Web Key (JWK) data provided as
@@ -147,7 +223,7 @@ func FromJWK(jwk []byte, gateway string) (*Wallet, error) {
 // Parameters:
 //   - data: The data to include in the transaction (can be nil for AR transfers)
 //   - target: The target wallet address for AR transfers (empty string for data-only)
-//   - quantity: The amount of AR to transfer in Winston units ("0" for data-only)
+//   - quantity: The amount of AR to transfer (currency.Zero for data-only)
 //   - tags: Optional metadata tags (can be nil)
 //
 // Returns a new Transaction instance ready for signing.
@@ -156,11 +232,12 @@ func FromJWK(jwk []byte, gateway string) (*Wallet, error) {
 //
 //	// Data transaction
 //	tags := []tag.Tag{{Name: "Content-Type", Value: "text/plain"}}
-//	tx := wallet.CreateTransaction([]byte("Hello!"), "", "0", &tags)
+//	tx := wallet.CreateTransaction([]byte("Hello!"), "", currency.Zero, &tags)
 //
 //	// AR transfer
-//	tx := wallet.CreateTransaction(nil, targetAddr, "1000000000000", nil)
-func (w *Wallet) CreateTransaction(data []byte, target string, quantity string, tags *[]tag.Tag) *transaction.Transaction {
+//	amount, _ := currency.ParseAR("1")
+//	tx := wallet.CreateTransaction(nil, targetAddr, amount, nil)
+func (w *Wallet) CreateTransaction(data []byte, target string, quantity currency.Winston, tags *[]tag.Tag) *transaction.Transaction {
 	return transaction.New(data, target, quantity, tags)
 }
 
@@ -169,7 +246,8 @@ func (w *Wallet) CreateTransaction(data []byte, target string, quantity string,
 // This method performs several operations:
 // 1. Sets the transaction owner to this wallet's public key
 // 2. Gets the current transaction anchor from the network
-// 3. Calculates the required transaction fee
+// 3. Calculates the required transaction fee, including the target if set,
+// using the wallet Estimator when one is configured
 // 4. Signs the transaction with this wallet's private key
 //
 // Parameters:
@@ -180,7 +258,7 @@ func (w *Wallet) CreateTransaction(data []byte, target string, quantity string,
 //
 // Example:
 //
-//	tx := wallet.CreateTransaction(data, "", "0", nil)
+//	tx := wallet.CreateTransaction(data, "", currency.Zero, nil)
 //	signedTx, err := wallet.SignTransaction(tx)
 //	if err != nil {
 //		log.Printf("Failed to sign transaction: %v", err)
@@ -188,24 +266,56 @@ func (w *Wallet) CreateTransaction(data []byte, target string, quantity string,
 //	}
 //	fmt.Printf("Transaction signed with ID: %s\n", signedTx.ID)
 func (w *Wallet) SignTransaction(tx *transaction.Transaction) (*transaction.Transaction, error) {
-	tx.Owner = w.Signer.Owner()
+	if err := w.prepare(context.Background(), tx); err != nil {
+		return nil, err
+	}
+	if err := w.sign(tx); err != nil {
+		return nil, err
+	}
+	w.logger().Debug("transaction signed", "id", tx.ID, "anchor", tx.LastTx, "reward", tx.Reward.String())
+	return tx, nil
+}
+
+// sign signs a transaction and records the time it took.
+func (w *Wallet) sign(tx *transaction.Transaction) error {
+	defer metrics.Since(metrics.OrNop(w.Metrics), metrics.SIGN_DURATION, time.Now(), metrics.Label{Name: "kind", Value: "transaction"})
+	return tx.Sign(w.Signer)
+}
+
+// prepare sets the owner, anchor and reward of a transaction.
+func (w *Wallet) prepare(ctx context.Context, tx *transaction.Transaction) error {
+	return prepareTransaction(ctx, w.Client, w.Estimator, w.Signer.Owner(), tx)
+}
 
-	anchor, err := w.Client.GetTransactionAnchor()
+// prepareTransaction sets the owner, anchor and reward of a transaction.
+//
+// The reward is priced with the transaction target, since transfers to wallets
+// that do not exist yet include a new wallet fee. The estimator is used for
+// pricing when it is not nil.
+//
+// The price is for the size of the raw data, not of its base64url encoding
+// in tx.Data, which is a third larger.
+func prepareTransaction(ctx context.Context, c *client.Client, e *pricing.Estimator, owner string, tx *transaction.Transaction) error {
+	tx.Owner = owner
+
+	anchor, err := c.GetTransactionAnchorContext(ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	tx.LastTx = anchor
 
-	reward, err := w.Client.GetTransactionPrice(len(tx.Data), "")
+	var reward currency.Winston
+	size := base64.RawURLEncoding.DecodedLen(len(tx.Data))
+	if e != nil {
+		reward, err = e.Estimate(ctx, size, tx.Target)
+	} else {
+		reward, err = c.GetTransactionPriceContext(ctx, size, tx.Target)
+	}
 	if err != nil {
-		return nil, err
+		return err
 	}
 	tx.Reward = reward
-
-	if err = tx.Sign(w.Signer); err != nil {
-		return nil, err
-	}
-	return tx, nil
+	return nil
 }
 
 // SendTransaction sends a signed transaction to the Arweave network.
@@ -234,12 +344,22 @@ func (w *Wallet) SendTransaction(tx *transaction.Transaction) error {
 	if err != nil {
 		return err
 	}
+	if w.Logger != nil {
+		tu.Logger = w.Logger
+	}
 	if err = tu.PostTransaction(); err != nil {
+		w.logger().Debug("transaction send failed", "id", tx.ID, "error", err)
 		return err
 	}
+	w.logger().Debug("transaction sent", "id", tx.ID, "status", tu.LastResponseStatus)
 	return nil
 }
 
+// logger returns the Logger of the wallet, or a logger that discards records.
+func (w *Wallet) logger() *slog.Logger {
+	return logging.OrDiscard(w.Logger)
+}
+
 // CreateDataItem creates a new ANS-104 data item.
 //
 // Data items are a more efficient way to upload data to Arweave when using
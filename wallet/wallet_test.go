@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/liteseed/goar/client"
+	"github.com/liteseed/goar/currency"
 	"github.com/liteseed/goar/transaction"
 	"github.com/stretchr/testify/assert"
 )
@@ -21,7 +22,7 @@ func mine(t *testing.T, c *client.Client) {
 
 func createTransaction(t *testing.T, w *Wallet) *transaction.Transaction {
 	data := []byte{1, 2, 3}
-	tx := transaction.New(data, "", "0", nil)
+	tx := transaction.New(data, "", currency.Zero, nil)
 
 	tx.Owner = w.Signer.Owner()
 
@@ -45,7 +46,7 @@ func TestSignTransaction(t *testing.T) {
 	data := []byte{1, 2, 3}
 
 	t.Run("Sign", func(t *testing.T) {
-		tx := transaction.New(data, "", "0", nil)
+		tx := transaction.New(data, "", currency.Zero, nil)
 		tx, err = w.SignTransaction(tx)
 		assert.NoError(t, err)
 		assert.NotEmpty(t, tx.ID)